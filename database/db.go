@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
@@ -30,7 +31,35 @@ func getConnectionStr() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", host, port, user, password, dbname)
 }
 
+// NewDB connects to Postgres and brings the schema up to date,
+// unless DB_AUTO_MIGRATE is set to "false".
 func NewDB() (*DB, error) {
+	db, err := Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	if os.Getenv("DB_AUTO_MIGRATE") == "false" {
+		slog.Info("Automatic migrations disabled")
+		return db, nil
+	}
+
+	migrator, err := NewMigrator(db.conn)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		slog.Error("Database migration failed", "error", err)
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate db: %w", err)
+	}
+
+	return db, nil
+}
+
+// Connect opens and pings the database without touching the schema.
+func Connect() (*DB, error) {
 	connStr := getConnectionStr()
 	host := os.Getenv("DB_HOST")
 	dbname := os.Getenv("DB_NAME")
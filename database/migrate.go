@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey is the pg_advisory_lock key shared by every backend instance,
+// so only one of them applies migrations at a time.
+const migrationLockKey = 7_214_331_902
+
+type Migration struct {
+	Version int
+	Name    string
+	UpSQL   string
+	DownSQL string
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// loadMigrations reads files named <version>_<name>.(up|down).sql and pairs them by version.
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations dir: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(fileName, ".sql") {
+			continue
+		}
+
+		base := strings.TrimSuffix(fileName, ".sql")
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s must end with .up.sql or .down.sql", fileName)
+		}
+		base = strings.TrimSuffix(base, "."+direction)
+
+		versionStr, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s must be named <version>_<name>", fileName)
+		}
+		version, err := strconv.Atoi(versionStr)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid version in migration %s", fileName)
+		}
+
+		body, err := fs.ReadFile(fsys, "migrations/"+fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", fileName, err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration version %d used by both %s and %s", version, m.Name, name)
+		}
+
+		if direction == "up" {
+			m.UpSQL = string(body)
+		} else {
+			m.DownSQL = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpSQL == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every migration newer than the current schema version.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		applied := 0
+		for _, mig := range m.migrations {
+			if mig.Version <= current {
+				continue
+			}
+			slog.Info("Applying migration", "version", mig.Version, "name", mig.Name)
+			if err := runMigration(ctx, conn, mig.UpSQL,
+				`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", mig.Version, mig.Name, err)
+			}
+			applied++
+		}
+
+		slog.Info("Database schema is up to date", "applied", applied)
+		return nil
+	})
+}
+
+// Down rolls back the given number of most recently applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive")
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		for i := 0; i < steps; i++ {
+			current, err := currentVersion(ctx, conn)
+			if err != nil {
+				return err
+			}
+			if current == 0 {
+				slog.Info("No migrations left to roll back")
+				return nil
+			}
+
+			mig, ok := m.find(current)
+			if !ok {
+				return fmt.Errorf("applied migration %d is unknown to this build", current)
+			}
+			if mig.DownSQL == "" {
+				return fmt.Errorf("migration %d_%s has no down file", mig.Version, mig.Name)
+			}
+
+			slog.Info("Rolling back migration", "version", mig.Version, "name", mig.Name)
+			if err := runMigration(ctx, conn, mig.DownSQL,
+				`DELETE FROM schema_migrations WHERE version = $1`, mig.Version); err != nil {
+				return fmt.Errorf("rollback of %d_%s failed: %w", mig.Version, mig.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Version returns the latest applied migration version, or 0 on an empty database.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get db connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := ensureMigrationsTable(ctx, conn); err != nil {
+		return 0, err
+	}
+	return currentVersion(ctx, conn)
+}
+
+func (m *Migrator) find(version int) (Migration, bool) {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return mig, true
+		}
+	}
+	return Migration{}, false
+}
+
+// withLock pins a single connection, since advisory locks are held per session.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get db connection: %w", err)
+	}
+	defer conn.Close()
+
+	slog.Debug("Waiting for migration lock")
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+			slog.Error("Failed to release migration lock", "error", err)
+		}
+	}()
+
+	if err := ensureMigrationsTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func ensureMigrationsTable(ctx context.Context, conn *sql.Conn) error {
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INT PRIMARY KEY,
+		name       VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+	)`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+func currentVersion(ctx context.Context, conn *sql.Conn) (int, error) {
+	var version int
+	err := conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// runMigration executes the migration body and the bookkeeping statement in one transaction.
+func runMigration(ctx context.Context, conn *sql.Conn, body string, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
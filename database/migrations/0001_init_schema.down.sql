@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS reviews;
+DROP TABLE IF EXISTS itinerary_activities;
+DROP TABLE IF EXISTS trip_itinerary;
+DROP TABLE IF EXISTS trips;
+DROP TABLE IF EXISTS user_preferences;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS flights;
+DROP TABLE IF EXISTS restaurants;
+DROP TABLE IF EXISTS hotels;
+DROP TABLE IF EXISTS attractions;
+DROP TABLE IF EXISTS cities;
+DROP TABLE IF EXISTS countries;
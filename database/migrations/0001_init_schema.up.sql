@@ -0,0 +1,153 @@
+CREATE TABLE IF NOT EXISTS countries (
+    country_id  SERIAL PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    code        VARCHAR(3)   NOT NULL UNIQUE,
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS cities (
+    city_id     SERIAL PRIMARY KEY,
+    country_id  INT              NOT NULL REFERENCES countries(country_id) ON DELETE CASCADE,
+    name        VARCHAR(255)     NOT NULL,
+    latitude    DOUBLE PRECISION NOT NULL,
+    longitude   DOUBLE PRECISION NOT NULL,
+    iata_code   VARCHAR(3),
+    description TEXT             NOT NULL DEFAULT '',
+    created_at  TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP        NOT NULL DEFAULT NOW(),
+    UNIQUE (latitude, longitude, country_id)
+);
+
+CREATE TABLE IF NOT EXISTS attractions (
+    attraction_id SERIAL PRIMARY KEY,
+    city_id       INT              NOT NULL REFERENCES cities(city_id) ON DELETE CASCADE,
+    name          VARCHAR(255)     NOT NULL,
+    category      VARCHAR(100)     NOT NULL DEFAULT '',
+    latitude      DOUBLE PRECISION NOT NULL,
+    longitude     DOUBLE PRECISION NOT NULL,
+    rating        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    entry_fee     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    website       TEXT             NOT NULL DEFAULT '',
+    created_at    TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP        NOT NULL DEFAULT NOW(),
+    UNIQUE (name, city_id)
+);
+
+CREATE TABLE IF NOT EXISTS hotels (
+    hotel_id        SERIAL PRIMARY KEY,
+    city_id         INT              NOT NULL REFERENCES cities(city_id) ON DELETE CASCADE,
+    name            VARCHAR(255)     NOT NULL,
+    address         TEXT             NOT NULL DEFAULT '',
+    stars           INT              NOT NULL DEFAULT 0,
+    rating          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    price_per_night DOUBLE PRECISION NOT NULL DEFAULT 0,
+    website         TEXT             NOT NULL DEFAULT '',
+    description     TEXT             NOT NULL DEFAULT '',
+    created_at      TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP        NOT NULL DEFAULT NOW(),
+    UNIQUE (name, city_id)
+);
+
+CREATE TABLE IF NOT EXISTS restaurants (
+    restaurant_id SERIAL PRIMARY KEY,
+    city_id       INT              NOT NULL REFERENCES cities(city_id) ON DELETE CASCADE,
+    name          VARCHAR(255)     NOT NULL,
+    cuisine       VARCHAR(255)     NOT NULL DEFAULT '',
+    latitude      DOUBLE PRECISION NOT NULL,
+    longitude     DOUBLE PRECISION NOT NULL,
+    rating        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    price_range   VARCHAR(10)      NOT NULL DEFAULT '',
+    website       TEXT             NOT NULL DEFAULT '',
+    created_at    TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP        NOT NULL DEFAULT NOW(),
+    UNIQUE (city_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS flights (
+    flight_id        SERIAL PRIMARY KEY,
+    from_city_id     INT              NOT NULL REFERENCES cities(city_id) ON DELETE CASCADE,
+    to_city_id       INT              NOT NULL REFERENCES cities(city_id) ON DELETE CASCADE,
+    airline          VARCHAR(255)     NOT NULL,
+    duration_minutes INT              NOT NULL DEFAULT 0,
+    price            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    website          TEXT             NOT NULL DEFAULT '',
+    created_at       TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP        NOT NULL DEFAULT NOW(),
+    UNIQUE (from_city_id, to_city_id, airline)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    user_id       SERIAL PRIMARY KEY,
+    first_name    VARCHAR(100) NOT NULL,
+    last_name     VARCHAR(100) NOT NULL,
+    email         VARCHAR(255) NOT NULL UNIQUE,
+    password_hash TEXT         NOT NULL,
+    created_at    TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    preference_id        SERIAL PRIMARY KEY,
+    user_id              INT       NOT NULL UNIQUE REFERENCES users(user_id) ON DELETE CASCADE,
+    home_city_id         INT       NOT NULL REFERENCES cities(city_id),
+    preferred_categories TEXT      NOT NULL DEFAULT '',
+    created_at           TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS trips (
+    trip_id             SERIAL PRIMARY KEY,
+    user_id             INT              NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    destination_city_id INT              NOT NULL REFERENCES cities(city_id),
+    title               VARCHAR(255)     NOT NULL,
+    start_date          DATE,
+    end_date            DATE,
+    duration            INT,
+    total_price         DOUBLE PRECISION,
+    status              VARCHAR(20),
+    created_at          TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP        NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trips_user_id ON trips(user_id);
+
+CREATE TABLE IF NOT EXISTS trip_itinerary (
+    itinerary_id SERIAL PRIMARY KEY,
+    trip_id      INT       NOT NULL REFERENCES trips(trip_id) ON DELETE CASCADE,
+    day_number   INT       NOT NULL,
+    notes        TEXT      NOT NULL DEFAULT '',
+    date         DATE      NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (trip_id, day_number)
+);
+
+CREATE TABLE IF NOT EXISTS itinerary_activities (
+    activity_id   BIGSERIAL PRIMARY KEY,
+    itinerary_id  INT         NOT NULL REFERENCES trip_itinerary(itinerary_id) ON DELETE CASCADE,
+    activity_type VARCHAR(20) NOT NULL,
+    hotel_id      INT REFERENCES hotels(hotel_id),
+    attraction_id INT REFERENCES attractions(attraction_id),
+    restaurant_id INT REFERENCES restaurants(restaurant_id),
+    flight_id     INT REFERENCES flights(flight_id),
+    order_number  INT         NOT NULL DEFAULT 0,
+    start_time    TIMESTAMP   NOT NULL,
+    end_time      TIMESTAMP   NOT NULL,
+    notes         TEXT        NOT NULL DEFAULT '',
+    created_at    TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_itinerary_activities_itinerary_id ON itinerary_activities(itinerary_id);
+
+CREATE TABLE IF NOT EXISTS reviews (
+    review_id   SERIAL PRIMARY KEY,
+    user_id     INT         NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('hotel', 'attraction', 'restaurant')),
+    entity_id   INT         NOT NULL,
+    rating      INT         NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment     TEXT        NOT NULL DEFAULT '',
+    review_date TIMESTAMP   NOT NULL DEFAULT NOW(),
+    created_at  TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_entity ON reviews(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_reviews_user_id ON reviews(user_id);
@@ -0,0 +1,4 @@
+ALTER TABLE trip_itinerary DROP COLUMN IF EXISTS route_distance_km;
+
+ALTER TABLE hotels DROP COLUMN IF EXISTS longitude;
+ALTER TABLE hotels DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,4 @@
+ALTER TABLE hotels ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE hotels ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+ALTER TABLE trip_itinerary ADD COLUMN IF NOT EXISTS route_distance_km DOUBLE PRECISION NOT NULL DEFAULT 0;
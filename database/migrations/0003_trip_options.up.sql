@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS trip_options (
+    option_id          SERIAL PRIMARY KEY,
+    trip_id            INT              NOT NULL REFERENCES trips(trip_id) ON DELETE CASCADE,
+    tier               VARCHAR(20)      NOT NULL,
+    outbound_flight_id INT              NOT NULL REFERENCES flights(flight_id),
+    inbound_flight_id  INT              NOT NULL REFERENCES flights(flight_id),
+    hotel_id           INT              NOT NULL REFERENCES hotels(hotel_id),
+    logistics_budget   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    activities_budget  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    more_money         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    total_price        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at         TIMESTAMP        NOT NULL DEFAULT NOW(),
+    UNIQUE (trip_id, tier)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE trips DROP COLUMN IF EXISTS planning_updated_at;
+ALTER TABLE trips DROP COLUMN IF EXISTS planning_error;
+ALTER TABLE trips DROP COLUMN IF EXISTS planning_status;
@@ -0,0 +1,6 @@
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS planning_status VARCHAR(20) NOT NULL DEFAULT 'queued';
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS planning_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS planning_updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- Trips created before status tracking already went through option generation.
+UPDATE trips SET planning_status = 'options_ready';
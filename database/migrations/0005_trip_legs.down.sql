@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS trip_option_legs;
+DROP TABLE IF EXISTS trip_legs;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS trip_legs (
+    leg_id         SERIAL PRIMARY KEY,
+    trip_id        INT  NOT NULL REFERENCES trips(trip_id) ON DELETE CASCADE,
+    leg_order      INT  NOT NULL,
+    city_id        INT  NOT NULL REFERENCES cities(city_id),
+    arrival_date   DATE NOT NULL,
+    departure_date DATE NOT NULL,
+    UNIQUE (trip_id, leg_order),
+    CHECK (departure_date > arrival_date)
+);
+
+CREATE TABLE IF NOT EXISTS trip_option_legs (
+    option_id         INT NOT NULL REFERENCES trip_options(option_id) ON DELETE CASCADE,
+    leg_order         INT NOT NULL,
+    city_id           INT NOT NULL REFERENCES cities(city_id),
+    nights            INT NOT NULL,
+    hotel_id          INT NOT NULL REFERENCES hotels(hotel_id),
+    arrival_flight_id INT NOT NULL REFERENCES flights(flight_id),
+    PRIMARY KEY (option_id, leg_order)
+);
+
+-- Every existing trip is a single leg to its destination city.
+INSERT INTO trip_legs (trip_id, leg_order, city_id, arrival_date, departure_date)
+SELECT trip_id, 0, destination_city_id, start_date, end_date
+FROM trips
+WHERE start_date IS NOT NULL AND end_date IS NOT NULL AND end_date > start_date
+ON CONFLICT DO NOTHING;
+
+INSERT INTO trip_option_legs (option_id, leg_order, city_id, nights, hotel_id, arrival_flight_id)
+SELECT o.option_id, 0, t.destination_city_id, t.end_date - t.start_date, o.hotel_id, o.outbound_flight_id
+FROM trip_options o
+JOIN trips t ON o.trip_id = t.trip_id
+WHERE t.start_date IS NOT NULL AND t.end_date IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+ALTER TABLE trip_options
+    DROP COLUMN IF EXISTS per_person_price,
+    DROP COLUMN IF EXISTS hotel_cost,
+    DROP COLUMN IF EXISTS flights_cost,
+    DROP COLUMN IF EXISTS rooms;
+
+ALTER TABLE trips
+    DROP COLUMN IF EXISTS children,
+    DROP COLUMN IF EXISTS adults;
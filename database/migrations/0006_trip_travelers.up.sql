@@ -0,0 +1,12 @@
+ALTER TABLE trips
+    ADD COLUMN IF NOT EXISTS adults   INT NOT NULL DEFAULT 1 CHECK (adults >= 1),
+    ADD COLUMN IF NOT EXISTS children INT NOT NULL DEFAULT 0 CHECK (children >= 0);
+
+ALTER TABLE trip_options
+    ADD COLUMN IF NOT EXISTS rooms            INT              NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS flights_cost     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS hotel_cost       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS per_person_price DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Options generated before this migration were priced for one traveler.
+UPDATE trip_options SET per_person_price = total_price;
@@ -0,0 +1,3 @@
+ALTER TABLE trip_options DROP COLUMN IF EXISTS budget_profile;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS budget_profile_id;
+DROP TABLE IF EXISTS budget_profiles;
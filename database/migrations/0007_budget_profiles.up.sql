@@ -0,0 +1,30 @@
+-- Shares are percentages of the trip budget; flight_share is the part of logistics spent on flights.
+-- Built-in profiles have no owner.
+CREATE TABLE IF NOT EXISTS budget_profiles (
+    profile_id       SERIAL PRIMARY KEY,
+    user_id          INT              REFERENCES users(user_id) ON DELETE CASCADE,
+    name             VARCHAR(100)     NOT NULL,
+    logistics_share  DOUBLE PRECISION NOT NULL CHECK (logistics_share > 0 AND logistics_share <= 100),
+    activities_share DOUBLE PRECISION NOT NULL CHECK (activities_share >= 0 AND activities_share <= 100),
+    extra_share      DOUBLE PRECISION NOT NULL CHECK (extra_share >= 0 AND extra_share <= 100),
+    flight_share     DOUBLE PRECISION NOT NULL CHECK (flight_share > 0 AND flight_share < 100),
+    created_at       TIMESTAMP        NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name),
+    CHECK (abs(logistics_share + activities_share + extra_share - 100) < 0.01)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS budget_profiles_builtin_name_idx
+    ON budget_profiles (name) WHERE user_id IS NULL;
+
+INSERT INTO budget_profiles (user_id, name, logistics_share, activities_share, extra_share, flight_share) VALUES
+    (NULL, 'balanced',   50, 30, 20, 60),
+    (NULL, 'foodie',     40, 25, 35, 60),
+    (NULL, 'backpacker', 45, 40, 15, 70),
+    (NULL, 'comfort',    60, 25, 15, 45)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE user_preferences
+    ADD COLUMN IF NOT EXISTS budget_profile_id INT REFERENCES budget_profiles(profile_id) ON DELETE SET NULL;
+
+ALTER TABLE trip_options
+    ADD COLUMN IF NOT EXISTS budget_profile VARCHAR(100) NOT NULL DEFAULT 'balanced';
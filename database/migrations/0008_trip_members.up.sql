@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS trip_members (
+    trip_id    INT         NOT NULL REFERENCES trips(trip_id) ON DELETE CASCADE,
+    user_id    INT         NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    role       VARCHAR(20) NOT NULL CHECK (role IN ('editor', 'viewer')),
+    invited_by INT         REFERENCES users(user_id) ON DELETE SET NULL,
+    created_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (trip_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS trip_members_user_id_idx ON trip_members (user_id);
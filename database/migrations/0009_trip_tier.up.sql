@@ -0,0 +1,3 @@
+-- Tier of the option the trip was finalized with, so single days can be replanned consistently.
+ALTER TABLE trips
+    ADD COLUMN IF NOT EXISTS tier VARCHAR(20);
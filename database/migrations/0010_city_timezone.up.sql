@@ -0,0 +1,3 @@
+-- IANA timezone of the city (e.g. Europe/Paris). Itinerary times are stored as local wall-clock time.
+ALTER TABLE cities
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_restaurants_cuisine_trgm;
+DROP INDEX IF EXISTS idx_restaurants_name_trgm;
+DROP INDEX IF EXISTS idx_hotels_name_trgm;
+DROP INDEX IF EXISTS idx_attractions_name_trgm;
+DROP INDEX IF EXISTS idx_cities_name_trgm;
+
+DROP INDEX IF EXISTS idx_restaurants_search_document;
+DROP INDEX IF EXISTS idx_hotels_search_document;
+DROP INDEX IF EXISTS idx_attractions_search_document;
+DROP INDEX IF EXISTS idx_cities_search_document;
+
+ALTER TABLE restaurants DROP COLUMN IF EXISTS search_document;
+ALTER TABLE hotels DROP COLUMN IF EXISTS search_document;
+ALTER TABLE attractions DROP COLUMN IF EXISTS search_document;
+ALTER TABLE cities DROP COLUMN IF EXISTS search_document;
@@ -0,0 +1,38 @@
+-- Full-text documents and trigram indexes backing /api/search.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE cities
+    ADD COLUMN IF NOT EXISTS search_document tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'C')
+    ) STORED;
+
+ALTER TABLE attractions
+    ADD COLUMN IF NOT EXISTS search_document tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(category, '')), 'B')
+    ) STORED;
+
+ALTER TABLE hotels
+    ADD COLUMN IF NOT EXISTS search_document tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(address, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'C')
+    ) STORED;
+
+ALTER TABLE restaurants
+    ADD COLUMN IF NOT EXISTS search_document tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(cuisine, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_cities_search_document ON cities USING GIN (search_document);
+CREATE INDEX IF NOT EXISTS idx_attractions_search_document ON attractions USING GIN (search_document);
+CREATE INDEX IF NOT EXISTS idx_hotels_search_document ON hotels USING GIN (search_document);
+CREATE INDEX IF NOT EXISTS idx_restaurants_search_document ON restaurants USING GIN (search_document);
+
+CREATE INDEX IF NOT EXISTS idx_cities_name_trgm ON cities USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_attractions_name_trgm ON attractions USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_hotels_name_trgm ON hotels USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_restaurants_name_trgm ON restaurants USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_restaurants_cuisine_trgm ON restaurants USING GIN (cuisine gin_trgm_ops);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_restaurants_coordinates;
+DROP INDEX IF EXISTS idx_hotels_coordinates;
+DROP INDEX IF EXISTS idx_attractions_coordinates;
@@ -0,0 +1,4 @@
+-- Bounding-box prefilter for /api/nearby.
+CREATE INDEX IF NOT EXISTS idx_attractions_coordinates ON attractions(latitude, longitude);
+CREATE INDEX IF NOT EXISTS idx_hotels_coordinates ON hotels(latitude, longitude);
+CREATE INDEX IF NOT EXISTS idx_restaurants_coordinates ON restaurants(latitude, longitude);
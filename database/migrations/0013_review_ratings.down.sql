@@ -0,0 +1,12 @@
+ALTER TABLE restaurants
+    DROP COLUMN IF EXISTS blended_rating,
+    DROP COLUMN IF EXISTS review_average,
+    DROP COLUMN IF EXISTS review_count;
+ALTER TABLE hotels
+    DROP COLUMN IF EXISTS blended_rating,
+    DROP COLUMN IF EXISTS review_average,
+    DROP COLUMN IF EXISTS review_count;
+ALTER TABLE attractions
+    DROP COLUMN IF EXISTS blended_rating,
+    DROP COLUMN IF EXISTS review_average,
+    DROP COLUMN IF EXISTS review_count;
@@ -0,0 +1,45 @@
+-- Review aggregates per place, refreshed whenever a review is created or deleted.
+-- blended_rating is a Bayesian average: the source rating acts as 5 prior votes
+-- (3.0 when the source has none) and pulls the review average towards it until
+-- enough reviews accumulate.
+ALTER TABLE attractions
+    ADD COLUMN IF NOT EXISTS review_count   INT              NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS review_average DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE attractions
+    ADD COLUMN IF NOT EXISTS blended_rating DOUBLE PRECISION GENERATED ALWAYS AS (
+        (5 * CASE WHEN rating > 0 THEN rating ELSE 3.0 END + review_count * review_average) / (5 + review_count)
+    ) STORED;
+
+ALTER TABLE hotels
+    ADD COLUMN IF NOT EXISTS review_count   INT              NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS review_average DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE hotels
+    ADD COLUMN IF NOT EXISTS blended_rating DOUBLE PRECISION GENERATED ALWAYS AS (
+        (5 * CASE WHEN rating > 0 THEN rating ELSE 3.0 END + review_count * review_average) / (5 + review_count)
+    ) STORED;
+
+ALTER TABLE restaurants
+    ADD COLUMN IF NOT EXISTS review_count   INT              NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS review_average DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE restaurants
+    ADD COLUMN IF NOT EXISTS blended_rating DOUBLE PRECISION GENERATED ALWAYS AS (
+        (5 * CASE WHEN rating > 0 THEN rating ELSE 3.0 END + review_count * review_average) / (5 + review_count)
+    ) STORED;
+
+UPDATE attractions a
+SET review_count = s.n, review_average = s.average
+FROM (SELECT entity_id, count(*) AS n, avg(rating) AS average
+      FROM reviews WHERE entity_type = 'attraction' GROUP BY entity_id) s
+WHERE a.attraction_id = s.entity_id;
+
+UPDATE hotels h
+SET review_count = s.n, review_average = s.average
+FROM (SELECT entity_id, count(*) AS n, avg(rating) AS average
+      FROM reviews WHERE entity_type = 'hotel' GROUP BY entity_id) s
+WHERE h.hotel_id = s.entity_id;
+
+UPDATE restaurants r
+SET review_count = s.n, review_average = s.average
+FROM (SELECT entity_id, count(*) AS n, avg(rating) AS average
+      FROM reviews WHERE entity_type = 'restaurant' GROUP BY entity_id) s
+WHERE r.restaurant_id = s.entity_id;
@@ -0,0 +1,19 @@
+-- Reviews are limited to places from the author's completed trips and marked as verified.
+-- Reviews written before the rule stay, verified only if they would pass it today.
+ALTER TABLE reviews
+    ADD COLUMN IF NOT EXISTS verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE reviews rv
+SET verified = TRUE
+WHERE EXISTS (
+    SELECT 1
+    FROM itinerary_activities ia
+    JOIN trip_itinerary ti ON ia.itinerary_id = ti.itinerary_id
+    JOIN trips t ON ti.trip_id = t.trip_id
+    WHERE t.user_id = rv.user_id AND t.status = 'Completed'
+      AND CASE rv.entity_type
+              WHEN 'hotel'      THEN ia.hotel_id
+              WHEN 'attraction' THEN ia.attraction_id
+              WHEN 'restaurant' THEN ia.restaurant_id
+          END = rv.entity_id
+);
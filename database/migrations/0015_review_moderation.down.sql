@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS review_edits;
+
+DROP INDEX IF EXISTS idx_reviews_status;
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS moderation_note,
+    DROP COLUMN IF EXISTS moderated_at,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS flags,
+    DROP COLUMN IF EXISTS status;
+
+DROP INDEX IF EXISTS idx_reviews_one_per_place;
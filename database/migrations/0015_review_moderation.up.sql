@@ -0,0 +1,63 @@
+-- Previous versions of edited reviews.
+CREATE TABLE IF NOT EXISTS review_edits (
+    edit_id   SERIAL PRIMARY KEY,
+    review_id INT       NOT NULL REFERENCES reviews(review_id) ON DELETE CASCADE,
+    rating    INT       NOT NULL,
+    comment   TEXT      NOT NULL DEFAULT '',
+    edited_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_edits_review_id ON review_edits(review_id);
+
+-- One review per user and place: keep the latest of any duplicates and file the
+-- older ones as its previous versions.
+INSERT INTO review_edits (review_id, rating, comment, edited_at)
+SELECT latest.review_id, rv.rating, rv.comment, rv.created_at
+FROM reviews rv
+JOIN LATERAL (
+    SELECT max(review_id) AS review_id
+    FROM reviews same
+    WHERE same.user_id = rv.user_id
+      AND same.entity_type = rv.entity_type
+      AND same.entity_id = rv.entity_id
+) latest ON latest.review_id > rv.review_id;
+
+DELETE FROM reviews rv
+USING reviews newer
+WHERE newer.user_id = rv.user_id
+  AND newer.entity_type = rv.entity_type
+  AND newer.entity_id = rv.entity_id
+  AND newer.review_id > rv.review_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reviews_one_per_place ON reviews(user_id, entity_type, entity_id);
+
+-- Flagged reviews wait in the queue as 'pending'; only 'approved' reviews are public and rated.
+ALTER TABLE reviews
+    ADD COLUMN IF NOT EXISTS status          VARCHAR(20) NOT NULL DEFAULT 'approved'
+        CHECK (status IN ('pending', 'approved', 'rejected')),
+    ADD COLUMN IF NOT EXISTS flags           TEXT[]      NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS updated_at      TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS moderated_by    INT REFERENCES users(user_id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS moderated_at    TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS moderation_note TEXT        NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_reviews_status ON reviews(status, created_at);
+
+-- Duplicates removed above no longer count towards the aggregates.
+UPDATE attractions a
+SET review_count = s.n, review_average = s.average
+FROM (SELECT entity_id, count(*) AS n, avg(rating) AS average
+      FROM reviews WHERE entity_type = 'attraction' GROUP BY entity_id) s
+WHERE a.attraction_id = s.entity_id;
+
+UPDATE hotels h
+SET review_count = s.n, review_average = s.average
+FROM (SELECT entity_id, count(*) AS n, avg(rating) AS average
+      FROM reviews WHERE entity_type = 'hotel' GROUP BY entity_id) s
+WHERE h.hotel_id = s.entity_id;
+
+UPDATE restaurants r
+SET review_count = s.n, review_average = s.average
+FROM (SELECT entity_id, count(*) AS n, avg(rating) AS average
+      FROM reviews WHERE entity_type = 'restaurant' GROUP BY entity_id) s
+WHERE r.restaurant_id = s.entity_id;
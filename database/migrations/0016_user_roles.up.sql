@@ -0,0 +1,3 @@
+-- Every account is a plain user unless promoted to admin.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin'));
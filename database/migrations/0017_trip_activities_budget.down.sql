@@ -0,0 +1,2 @@
+ALTER TABLE trips
+    DROP COLUMN IF EXISTS activities_budget;
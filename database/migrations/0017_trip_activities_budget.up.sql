@@ -0,0 +1,4 @@
+-- Activities budget of the option the trip was finalized with. Regenerating options replaces
+-- the stored options, so single days are replanned against this copy instead.
+ALTER TABLE trips
+    ADD COLUMN IF NOT EXISTS activities_budget DOUBLE PRECISION;
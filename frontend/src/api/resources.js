@@ -1,12 +1,37 @@
 import client from './client'
 
 export const getCountries   = () => client.get('/api/countries').then((r) => r.data)
-export const getCities      = () => client.get('/api/cities').then((r) => r.data)
-export const getAttractions = () => client.get('/api/attractions').then((r) => r.data)
-export const getHotels      = () => client.get('/api/hotels').then((r) => r.data)
-export const getRestaurants = () => client.get('/api/restaurants').then((r) => r.data)
-export const getFlights     = () => client.get('/api/flights').then((r) => r.data)
+
+// List endpoints are cursor-paginated. getPage loads one page; pass its nextCursor back as
+// cursor to load the next one. Empty filter values are left out of the query.
+const getPage = (url, params = {}) => {
+  const query = Object.fromEntries(
+    Object.entries(params).filter(([, v]) => v !== '' && v !== undefined && v !== null),
+  )
+  return client.get(url, { params: query }).then(({ data }) => ({
+    items: data.data,
+    nextCursor: data.has_more ? data.next_cursor : undefined,
+  }))
+}
+
+// getAll follows next_cursor through every page; only meant for short lists like cities.
+const getAll = async (url, params = {}) => {
+  const items = []
+  let cursor
+  do {
+    const page = await getPage(url, { ...params, limit: 200, cursor })
+    items.push(...page.items)
+    cursor = page.nextCursor
+  } while (cursor)
+  return items
+}
+
+export const getCities      = (params) => getAll('/api/cities', params)
+export const getAttractions = (params) => getPage('/api/attractions', params)
+export const getHotels      = (params) => getPage('/api/hotels', params)
+export const getRestaurants = (params) => getPage('/api/restaurants', params)
+export const getFlights     = (params) => getPage('/api/flights', params)
 export const getVisitedEntities = async (type) => {
   const response = await client.get(`/api/users/me/visited?type=${type}`);
   return response.data;
-};
\ No newline at end of file
+};
@@ -12,6 +12,9 @@ export const createTrip = (data) =>
 export const generateTripOptions = (tripId) =>
   client.post(`/api/trips/${tripId}/generate-options`).then((r) => r.data)
 
+export const getTripOptions = (tripId) =>
+  client.get(`/api/trips/${tripId}/options`).then((r) => r.data)
+
 export const selectTripOption = (tripId, selection) =>
   client.post(`/api/trips/${tripId}/select-option`, selection).then((r) => r.data)
 
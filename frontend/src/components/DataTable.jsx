@@ -1,6 +1,7 @@
 import { useState } from 'react'
 
-export default function DataTable({ columns, data, searchKeys = [] }) {
+// Paged lists pass hasMore and onLoadMore to show a button that loads the next page.
+export default function DataTable({ columns, data, searchKeys = [], loading = false, hasMore = false, onLoadMore }) {
   const [query, setQuery] = useState('')
 
   const filtered = query
@@ -63,7 +64,14 @@ export default function DataTable({ columns, data, searchKeys = [] }) {
           </tbody>
         </table>
       </div>
-      <p className="text-xs text-gray-400">{filtered.length} of {data.length} records</p>
+      <div className="flex items-center justify-between">
+        <p className="text-xs text-gray-400">{filtered.length} of {data.length} records</p>
+        {onLoadMore && hasMore && (
+          <button type="button" className="btn-secondary text-sm" onClick={onLoadMore} disabled={loading}>
+            {loading ? 'Loading…' : 'Load more'}
+          </button>
+        )}
+      </div>
     </div>
   )
 }
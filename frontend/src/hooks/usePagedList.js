@@ -0,0 +1,46 @@
+import { useState, useEffect, useCallback, useRef } from 'react'
+
+// usePagedList loads the first page of a cursor-paginated list whenever the filter params
+// change, and appends the following page on loadMore.
+export default function usePagedList(fetchPage, params) {
+  const [items, setItems] = useState([])
+  const [nextCursor, setNextCursor] = useState()
+  const [loading, setLoading] = useState(true)
+  const [error, setError] = useState(null)
+
+  // Params are compared by value, so callers can pass a fresh object on every render.
+  const key = JSON.stringify(params)
+  const currentKey = useRef(key)
+  currentKey.current = key
+
+  useEffect(() => {
+    let cancelled = false
+    setLoading(true)
+    setError(null)
+    fetchPage(JSON.parse(key))
+      .then((page) => {
+        if (cancelled) return
+        setItems(page.items)
+        setNextCursor(page.nextCursor)
+      })
+      .catch((err) => !cancelled && setError(err))
+      .finally(() => !cancelled && setLoading(false))
+    return () => { cancelled = true }
+  }, [fetchPage, key])
+
+  const loadMore = useCallback(() => {
+    if (!nextCursor) return
+    setLoading(true)
+    fetchPage({ ...JSON.parse(key), cursor: nextCursor })
+      .then((page) => {
+        // Filters changed while the page was loading; it belongs to the old list.
+        if (currentKey.current !== key) return
+        setItems((prev) => [...prev, ...page.items])
+        setNextCursor(page.nextCursor)
+      })
+      .catch(setError)
+      .finally(() => setLoading(false))
+  }, [fetchPage, key, nextCursor])
+
+  return { items, loading, error, hasMore: Boolean(nextCursor), loadMore }
+}
@@ -1,5 +1,6 @@
 import { useState, useEffect } from 'react'
 import { getAttractions, getCities } from '../api/resources'
+import usePagedList from '../hooks/usePagedList'
 import PageHeader from '../components/PageHeader'
 import DataTable from '../components/DataTable'
 
@@ -13,9 +14,7 @@ const CATEGORY_COLORS = {
 }
 
 export default function Attractions() {
-  const [attractions, setAttractions] = useState([])
   const [cities, setCities] = useState([])
-  const [loading, setLoading] = useState(true)
   
   const [searchTerm, setSearchTerm] = useState('')
   const [selectedCityId, setSelectedCityId] = useState('')
@@ -23,33 +22,24 @@ export default function Attractions() {
   const [minRating, setMinRating] = useState('')
 
   useEffect(() => {
-    setLoading(true)
-    Promise.all([getAttractions(), getCities()])
-      .then(([attractionsData, citiesData]) => {
-        const sortedAttractions = (attractionsData || []).sort((a, b) => 
-          a.name.localeCompare(b.name)
-        );
-
-        const sortedCities = (citiesData || []).sort((a, b) => 
-          a.name.localeCompare(b.name)
-        );
-
-        setAttractions(sortedAttractions);
-        setCities(sortedCities);
-      })
+    getCities()
+      .then((citiesData) => setCities((citiesData || []).sort((a, b) => a.name.localeCompare(b.name))))
       .catch(err => console.error("Error loading data:", err))
-      .finally(() => setLoading(false))
   }, [])
 
+  // City, category and rating are filtered by the API one page at a time.
+  const { items: attractions, loading, error, hasMore, loadMore } = usePagedList(getAttractions, {
+    city_id: selectedCityId,
+    category: selectedCategory,
+    min_rating: minRating,
+    sort: 'name',
+  })
+
   const sortedCategories = Object.keys(CATEGORY_COLORS).sort((a, b) => a.localeCompare(b));
 
-  const filteredData = attractions.filter(item => {
-    const matchesSearch = item.name.toLowerCase().includes(searchTerm.toLowerCase())
-    const matchesCity = selectedCityId ? item.city_id === parseInt(selectedCityId) : true
-    const matchesCategory = selectedCategory ? item.category === selectedCategory : true
-    const matchesRating = minRating ? item.rating >= parseFloat(minRating) : true
-    return matchesSearch && matchesCity && matchesCategory && matchesRating
-  })
+  const filteredData = attractions.filter(item =>
+    item.name.toLowerCase().includes(searchTerm.toLowerCase())
+  )
 
   const columns = [
     { key: 'name', label: 'Attraction Name' },
@@ -88,6 +78,8 @@ export default function Attractions() {
     },
   ]
 
+  if (error) return <div className="p-8 text-red-600 font-medium">Failed to load attraction data.</div>
+
   return (
     <div>
       <PageHeader icon="🏛️" title="Attractions" subtitle={loading ? 'Loading points of interest...' : `${filteredData.length} spots to explore`} />
@@ -127,7 +119,7 @@ export default function Attractions() {
       </div>
 
       <div className="card shadow-md">
-        <DataTable columns={columns} data={filteredData} loading={loading} />
+        <DataTable columns={columns} data={filteredData} loading={loading} hasMore={hasMore} onLoadMore={loadMore} />
       </div>
     </div>
   )
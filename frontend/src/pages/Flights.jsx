@@ -1,5 +1,6 @@
 import { useState, useEffect } from 'react'
 import { getFlights, getCities } from '../api/resources'
+import usePagedList from '../hooks/usePagedList'
 import PageHeader from '../components/PageHeader'
 import DataTable from '../components/DataTable'
 
@@ -11,38 +12,24 @@ function formatDuration(minutes) {
 }
 
 export default function Flights() {
-  const [flights, setFlights] = useState([])
   const [cities, setCities] = useState([])
-  const [loading, setLoading] = useState(true)
-  const [error, setError] = useState('')
 
   const [fromCityId, setFromCityId] = useState('')
   const [toCityId, setToCityId] = useState('')
   const [maxPrice, setMaxPrice] = useState('')
 
   useEffect(() => {
-    setLoading(true)
-    Promise.all([getFlights(), getCities()])
-      .then(([flightsData, citiesData]) => {
-        const sortedCities = (citiesData || []).sort((a, b) => 
-          a.name.localeCompare(b.name)
-        );
-        
-        setFlights(flightsData || [])
-        setCities(sortedCities)
-      })
-      .catch(err => {
-        console.error("Error loading flights:", err)
-        setError("Failed to load flight data.")
-      })
-      .finally(() => setLoading(false))
+    getCities()
+      .then((citiesData) => setCities((citiesData || []).sort((a, b) => a.name.localeCompare(b.name))))
+      .catch(err => console.error("Error loading cities:", err))
   }, [])
 
-  const filteredFlights = flights.filter(flight => {
-    const matchesFrom = fromCityId ? flight.from_city_id === parseInt(fromCityId) : true
-    const matchesTo = toCityId ? flight.to_city_id === parseInt(toCityId) : true
-    const matchesPrice = maxPrice ? flight.price <= parseFloat(maxPrice) : true
-    return matchesFrom && matchesTo && matchesPrice
+  // Route and price are filtered by the API one page at a time, cheapest first.
+  const { items: flights, loading, error, hasMore, loadMore } = usePagedList(getFlights, {
+    from_city_id: fromCityId,
+    to_city_id: toCityId,
+    max_price: maxPrice,
+    sort: 'price',
   })
 
   const getCityName = (id) => cities.find(c => c.city_id === id)?.name || `ID: ${id}`
@@ -98,7 +85,7 @@ export default function Flights() {
     }
   ]
 
-  if (error) return <div className="p-8 text-red-600 font-medium">{error}</div>
+  if (error) return <div className="p-8 text-red-600 font-medium">Failed to load flight data.</div>
 
   return (
     <div>
@@ -134,7 +121,7 @@ export default function Flights() {
       </div>
 
       <div className="card shadow-md overflow-hidden">
-        <DataTable columns={columns} data={filteredFlights} loading={loading} />
+        <DataTable columns={columns} data={flights} loading={loading} hasMore={hasMore} onLoadMore={loadMore} />
       </div>
     </div>
   )
@@ -1,13 +1,11 @@
 import { useState, useEffect } from 'react'
 import { getHotels, getCities } from '../api/resources'
+import usePagedList from '../hooks/usePagedList'
 import PageHeader from '../components/PageHeader'
 import DataTable from '../components/DataTable'
 
 export default function Hotels() {
-  const [hotels, setHotels] = useState([])
   const [cities, setCities] = useState([])
-  const [loading, setLoading] = useState(true)
-  const [error, setError] = useState('')
 
   const [searchTerm, setSearchTerm] = useState('')
   const [selectedCityId, setSelectedCityId] = useState('')
@@ -16,36 +14,24 @@ export default function Hotels() {
   const [maxPrice, setMaxPrice] = useState('')
 
   useEffect(() => {
-    setLoading(true)
-    Promise.all([getHotels(), getCities()])
-      .then(([hotelsData, citiesData]) => {
-        const sortedHotels = (hotelsData || []).sort((a, b) => 
-          a.name.localeCompare(b.name)
-        );
-        
-        const sortedCities = (citiesData || []).sort((a, b) => 
-          a.name.localeCompare(b.name)
-        );
-
-        setHotels(sortedHotels);
-        setCities(sortedCities);
-      })
-      .catch(err => {
-        console.error("Error loading hotels:", err);
-        setError("Failed to load hotel data.");
-      })
-      .finally(() => setLoading(false));
-  }, []);
+    getCities()
+      .then((citiesData) => setCities((citiesData || []).sort((a, b) => a.name.localeCompare(b.name))))
+      .catch((err) => console.error("Error loading cities:", err))
+  }, [])
 
-  const filteredHotels = hotels.filter(hotel => {
-    const matchesSearch = hotel.name.toLowerCase().includes(searchTerm.toLowerCase())
-    const matchesCity = selectedCityId ? hotel.city_id === parseInt(selectedCityId) : true
-    const matchesStars = selectedStars ? hotel.stars === parseInt(selectedStars) : true
-    const matchesRating = minRating ? hotel.rating >= parseFloat(minRating) : true
-    const matchesPrice = maxPrice ? hotel.price_per_night <= parseFloat(maxPrice) : true
-    return matchesSearch && matchesCity && matchesStars && matchesRating && matchesPrice
+  // City, stars, rating and price are filtered by the API one page at a time.
+  const { items: hotels, loading, error, hasMore, loadMore } = usePagedList(getHotels, {
+    city_id: selectedCityId,
+    stars: selectedStars,
+    min_rating: minRating,
+    max_price: maxPrice,
+    sort: 'name',
   })
 
+  const filteredHotels = hotels.filter(hotel =>
+    hotel.name.toLowerCase().includes(searchTerm.toLowerCase())
+  )
+
   const columns = [
     { key: 'name', label: 'Hotel Name' },
     { 
@@ -96,7 +82,7 @@ export default function Hotels() {
     },
   ]
 
-  if (error) return <div className="p-8 text-red-600 font-medium">{error}</div>
+  if (error) return <div className="p-8 text-red-600 font-medium">Failed to load hotel data.</div>
 
   return (
     <div>
@@ -151,7 +137,7 @@ export default function Hotels() {
       </div>
 
       <div className="card shadow-lg">
-        <DataTable columns={columns} data={filteredHotels} loading={loading} />
+        <DataTable columns={columns} data={filteredHotels} loading={loading} hasMore={hasMore} onLoadMore={loadMore} />
       </div>
     </div>
   )
@@ -1,13 +1,11 @@
 import { useState, useEffect } from 'react'
 import { getRestaurants, getCities } from '../api/resources'
+import usePagedList from '../hooks/usePagedList'
 import PageHeader from '../components/PageHeader'
 import DataTable from '../components/DataTable'
 
 export default function Restaurants() {
-  const [data, setData] = useState([])
   const [cities, setCities] = useState([])
-  const [loading, setLoading] = useState(true)
-  const [error, setError] = useState('')
 
   const [searchTerm, setSearchTerm] = useState('')
   const [selectedCityId, setSelectedCityId] = useState('')
@@ -15,32 +13,27 @@ export default function Restaurants() {
   const [minRating, setMinRating] = useState('')
 
   useEffect(() => {
-    setLoading(true)
-    Promise.all([getRestaurants(), getCities()])
-      .then(([restData, citiesData]) => {
-        const sortedData = [...restData].sort((a, b) => a.name.localeCompare(b.name));
-        setData(sortedData);
-
-        const sortedCities = (citiesData || []).sort((a, b) => 
-          a.name.localeCompare(b.name)
-        );
-        setCities(sortedCities);
-      })
-      .catch(() => setError('Failed to load restaurant data.'))
-      .finally(() => setLoading(false))
+    getCities()
+      .then((citiesData) => setCities((citiesData || []).sort((a, b) => a.name.localeCompare(b.name))))
+      .catch((err) => console.error("Error loading cities:", err))
   }, [])
 
-  const cuisines = [...new Set(data.map(r => r.cuisine).filter(Boolean))].sort((a, b) => 
+  // City, cuisine and rating are filtered by the API one page at a time.
+  const { items: data, loading, error, hasMore, loadMore } = usePagedList(getRestaurants, {
+    city_id: selectedCityId,
+    cuisine: selectedCuisine,
+    min_rating: minRating,
+    sort: 'name',
+  })
+
+  // Cuisines come from the pages loaded so far; keep the selected one while it filters the list.
+  const cuisines = [...new Set([...data.map(r => r.cuisine), selectedCuisine].filter(Boolean))].sort((a, b) => 
     a.localeCompare(b)
   );
 
-  const filteredData = data.filter(item => {
-    const matchesSearch = item.name?.toLowerCase().includes(searchTerm.toLowerCase())
-    const matchesCity = selectedCityId ? item.city_id === parseInt(selectedCityId) : true
-    const matchesCuisine = selectedCuisine ? item.cuisine === selectedCuisine : true
-    const matchesRating = minRating ? item.rating >= parseFloat(minRating) : true
-    return matchesSearch && matchesCity && matchesCuisine && matchesRating
-  })
+  const filteredData = data.filter(item =>
+    item.name?.toLowerCase().includes(searchTerm.toLowerCase())
+  )
 
   const columns = [
     { key: 'name', label: 'Restaurant' },
@@ -88,7 +81,7 @@ export default function Restaurants() {
     },
   ]
 
-  if (error) return <div className="p-8 text-red-600">{error}</div>
+  if (error) return <div className="p-8 text-red-600">Failed to load restaurant data.</div>
 
   return (
     <div>
@@ -123,7 +116,7 @@ export default function Restaurants() {
       </div>
 
       <div className="card shadow-md">
-        <DataTable columns={columns} data={filteredData} loading={loading} />
+        <DataTable columns={columns} data={filteredData} loading={loading} hasMore={hasMore} onLoadMore={loadMore} />
       </div>
     </div>
   )
@@ -30,6 +30,8 @@ export default function CreateTrip() {
     end_date: '',
     duration: '',
     total_price: '',
+    adults: '1',
+    children: '0',
   })
 
   useEffect(() => {
@@ -80,6 +82,8 @@ export default function CreateTrip() {
         duration: duration,
         start_date: startDate,
         end_date: endDate,
+        adults: parseInt(form.adults) || 1,
+        children: parseInt(form.children) || 0,
       }
 
       const { trip_id } = await createTrip(payload)
@@ -97,12 +101,7 @@ export default function CreateTrip() {
   const handleSelect = async (opt) => {
     setLoading(true)
     try {
-      await selectTripOption(tripId, {
-        tier: opt.tier,
-        hotel_id: opt.hotel?.hotel_id || 0,
-        outbound_flight_id: opt.outbound_flight?.flight_id || 0,
-        inbound_flight_id: opt.inbound_flight?.flight_id || 0,
-      })
+      await selectTripOption(tripId, { option_id: opt.option_id })
       navigate(`/trips/${tripId}/itinerary`)
     } catch (err) {
       setError('Selection failed.')
@@ -192,6 +191,17 @@ export default function CreateTrip() {
               )}
             </div>
 
+            <div className="grid grid-cols-2 gap-4">
+              <div>
+                <label className="label">Adults</label>
+                <input type="number" className="input" value={form.adults} onChange={handleChange('adults')} min="1" required />
+              </div>
+              <div>
+                <label className="label">Children</label>
+                <input type="number" className="input" value={form.children} onChange={handleChange('children')} min="0" />
+              </div>
+            </div>
+
             <div>
               <label className="label">Budget (USD)</label>
               <input type="number" className="input" value={form.total_price} onChange={handleChange('total_price')} placeholder="e.g. 2000" required />
@@ -219,12 +229,12 @@ export default function CreateTrip() {
                       <div className="space-y-1">
                         <p className="text-[10px] font-black text-gray-400 uppercase tracking-tighter">Accommodation</p>
                         <p className="font-bold text-gray-900 leading-tight">{opt.hotel?.name || 'Local Guesthouse'}</p>
-                        <p className="text-xs text-gray-600">{opt.hotel?.stars} ⭐ · ${opt.hotel?.price_per_night}/night</p>
+                        <p className="text-xs text-gray-600">{opt.hotel?.stars} ⭐ · ${opt.hotel?.price_per_night}/night · {opt.rooms} room{opt.rooms > 1 ? 's' : ''}</p>
                       </div>
                       <div className="space-y-1">
                         <p className="text-[10px] font-black text-gray-400 uppercase tracking-tighter">Transport</p>
                         <p className="font-bold text-gray-900 leading-tight">{opt.outbound_flight?.airline || 'Standard Travel'}</p>
-                        <p className="text-xs text-gray-600">Round trip total: ${((opt.outbound_flight?.price || 0) + (opt.inbound_flight?.price || 0)).toLocaleString()}</p>
+                        <p className="text-xs text-gray-600">Flights for the group: ${opt.flights_cost?.toLocaleString()}</p>
                       </div>
                     </div>
                     
@@ -238,6 +248,7 @@ export default function CreateTrip() {
                   <div className="md:w-52 flex flex-col items-center justify-center bg-white rounded-xl p-5 border border-gray-100 shadow-inner">
                     <p className="text-xs text-gray-400 uppercase font-bold mb-1">Est. Total</p>
                     <p className="text-3xl font-black text-gray-900">${opt.total_price_of_money?.toLocaleString()}</p>
+                    <p className="text-xs text-gray-500 mt-1">${Math.round(opt.per_person_price || 0).toLocaleString()} per person</p>
                     <button onClick={() => handleSelect(opt)} disabled={loading} className="btn-primary w-full mt-4 justify-center py-3">Select Plan</button>
                   </div>
                 </div>
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"travel-planning/services"
+
+	"github.com/gorilla/mux"
+)
+
+// SeedJob is a catalog import that admins can start on demand.
+type SeedJob interface {
+	RunJob()
+}
+
+type AdminHandlers struct {
+	UserService *services.UserService
+
+	jobs    map[string]SeedJob
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+func NewAdminHandlers(userService *services.UserService, jobs map[string]SeedJob) *AdminHandlers {
+	return &AdminHandlers{
+		UserService: userService,
+		jobs:        jobs,
+		running:     make(map[string]bool),
+	}
+}
+
+// TriggerSeedJobHandler godoc
+// @Summary Run a seed job now
+// @Description Admin only. Starts the import in the background; a job that is already running is not started twice.
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param job path string true "countries, cities, attractions, hotels, restaurants or flights"
+// @Success 202 {object} map[string]string
+// @Failure 409 {string} string "Job already running"
+// @Router /api/admin/seed/{job} [post]
+func (h *AdminHandlers) TriggerSeedJobHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["job"]
+	job, ok := h.jobs[name]
+	if !ok {
+		http.Error(w, "Unknown seed job", http.StatusNotFound)
+		return
+	}
+
+	h.mu.Lock()
+	if h.running[name] {
+		h.mu.Unlock()
+		http.Error(w, "Seed job is already running", http.StatusConflict)
+		return
+	}
+	h.running[name] = true
+	h.mu.Unlock()
+
+	slog.Info("Seed job triggered", "job", name, "user_id", r.Header.Get("X-User-ID"))
+	go func() {
+		defer func() {
+			h.mu.Lock()
+			delete(h.running, name)
+			h.mu.Unlock()
+		}()
+		job.RunJob()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"job": name, "status": "started"})
+}
+
+// GetSeedJobsHandler godoc
+// @Summary List seed jobs
+// @Description Admin only. Available seed jobs and whether each is running.
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} map[string]interface{}
+// @Router /api/admin/seed [get]
+func (h *AdminHandlers) GetSeedJobsHandler(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0, len(h.jobs))
+	for name := range h.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h.mu.Lock()
+	jobs := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		jobs = append(jobs, map[string]interface{}{"job": name, "running": h.running[name]})
+	}
+	h.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(jobs)
+}
+
+type setRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// SetUserRoleHandler godoc
+// @Summary Change a user's role
+// @Description Admin only. The new role applies from the user's next login or token refresh.
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Param id path int true "User ID"
+// @Param body body setRoleRequest true "user or admin"
+// @Success 204 "No Content"
+// @Router /api/admin/users/{id}/role [put]
+func (h *AdminHandlers) SetUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || userID <= 0 {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+	if strconv.Itoa(userID) == r.Header.Get("X-User-ID") {
+		http.Error(w, "You cannot change your own role", http.StatusBadRequest)
+		return
+	}
+
+	var req setRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	err = h.UserService.SetUserRole(userID, req.Role)
+	switch {
+	case errors.Is(err, services.ErrInvalidRole):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, services.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	case err != nil:
+		slog.Error("Failed to set user role", "user_id", userID, "error", err)
+		http.Error(w, "Failed to set role", http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"travel-planning/models"
+	"travel-planning/services"
+
+	"github.com/gorilla/mux"
+)
+
+// UpdateAttractionHandler godoc
+// @Summary Edit an attraction
+// @Description Admin only. The city cannot be changed.
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Attraction ID"
+// @Param attraction body models.Attraction true "Attraction details"
+// @Success 200 {object} models.Attraction
+// @Router /api/admin/attractions/{id} [put]
+func (h *ResourceHandlers) UpdateAttractionHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := catalogID(w, r)
+	if !ok {
+		return
+	}
+	var req models.Attraction
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	attraction, err := h.ResourceService.UpdateAttraction(id, req)
+	writeCatalogUpdate(w, attraction, err, "attraction", id)
+}
+
+// UpdateHotelHandler godoc
+// @Summary Edit a hotel
+// @Description Admin only. The city cannot be changed.
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Hotel ID"
+// @Param hotel body models.Hotel true "Hotel details"
+// @Success 200 {object} models.Hotel
+// @Router /api/admin/hotels/{id} [put]
+func (h *ResourceHandlers) UpdateHotelHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := catalogID(w, r)
+	if !ok {
+		return
+	}
+	var req models.Hotel
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	hotel, err := h.ResourceService.UpdateHotel(id, req)
+	writeCatalogUpdate(w, hotel, err, "hotel", id)
+}
+
+// UpdateRestaurantHandler godoc
+// @Summary Edit a restaurant
+// @Description Admin only. The city cannot be changed.
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Restaurant ID"
+// @Param restaurant body models.Restaurant true "Restaurant details"
+// @Success 200 {object} models.Restaurant
+// @Router /api/admin/restaurants/{id} [put]
+func (h *ResourceHandlers) UpdateRestaurantHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := catalogID(w, r)
+	if !ok {
+		return
+	}
+	var req models.Restaurant
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	restaurant, err := h.ResourceService.UpdateRestaurant(id, req)
+	writeCatalogUpdate(w, restaurant, err, "restaurant", id)
+}
+
+func catalogID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeCatalogUpdate(w http.ResponseWriter, entry interface{}, err error, kind string, id int) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCatalogEntry):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, services.ErrCatalogEntryNotFound):
+		http.Error(w, "Not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrCatalogConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		slog.Error("Failed to update catalog entry", "kind", kind, "id", id, "error", err)
+		http.Error(w, "Failed to update "+kind, http.StatusInternalServerError)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(entry)
+	}
+}
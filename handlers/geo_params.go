@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"travel-planning/models"
+)
+
+// parseResourceFilter reads the catalog filters, ?bbox=minLon,minLat,maxLon,maxLat and the
+// paging options (limit, cursor, sort) from the query string.
+func parseResourceFilter(r *http.Request) (models.ResourceFilter, error) {
+	var filter models.ResourceFilter
+	q := r.URL.Query()
+
+	ids := []struct {
+		name string
+		dest *int
+	}{
+		{"city_id", &filter.CityID},
+		{"country_id", &filter.CountryID},
+		{"from_city_id", &filter.FromCityID},
+		{"to_city_id", &filter.ToCityID},
+		{"stars", &filter.Stars},
+		{"limit", &filter.Limit},
+	}
+	for _, p := range ids {
+		if raw := q.Get(p.name); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil || v <= 0 {
+				return filter, fmt.Errorf("%s must be a positive integer", p.name)
+			}
+			*p.dest = v
+		}
+	}
+
+	amounts := []struct {
+		name string
+		dest *float64
+	}{
+		{"min_rating", &filter.MinRating},
+		{"max_price", &filter.MaxPrice},
+	}
+	for _, p := range amounts {
+		if raw := q.Get(p.name); raw != "" {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil || v < 0 {
+				return filter, fmt.Errorf("%s must be a non-negative number", p.name)
+			}
+			*p.dest = v
+		}
+	}
+
+	filter.Category = strings.TrimSpace(q.Get("category"))
+	filter.Cuisine = strings.TrimSpace(q.Get("cuisine"))
+	filter.PriceRange = strings.TrimSpace(q.Get("price_range"))
+	filter.Cursor = q.Get("cursor")
+	filter.Sort = q.Get("sort")
+
+	if raw := q.Get("bbox"); raw != "" {
+		parts := strings.Split(raw, ",")
+		if len(parts) != 4 {
+			return filter, errors.New("bbox must be minLon,minLat,maxLon,maxLat")
+		}
+		var v [4]float64
+		for i, p := range parts {
+			f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+			if err != nil {
+				return filter, errors.New("bbox must contain four numbers")
+			}
+			v[i] = f
+		}
+		box := models.BoundingBox{MinLon: v[0], MinLat: v[1], MaxLon: v[2], MaxLat: v[3]}
+		if box.MinLat > box.MaxLat || box.MinLat < -90 || box.MaxLat > 90 ||
+			box.MinLon < -180 || box.MinLon > 180 || box.MaxLon < -180 || box.MaxLon > 180 {
+			return filter, errors.New("bbox is out of range")
+		}
+		filter.BBox = &box
+	}
+	return filter, nil
+}
+
+// wantsGeoJSON is true for ?format=geojson or an Accept header asking for application/geo+json.
+func wantsGeoJSON(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "geojson" ||
+		strings.Contains(r.Header.Get("Accept"), "application/geo+json")
+}
+
+func writeGeoJSON(w http.ResponseWriter, fc models.FeatureCollection) {
+	w.Header().Set("Content-Type", "application/geo+json")
+	if err := json.NewEncoder(w).Encode(fc); err != nil {
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"travel-planning/models"
+	"travel-planning/services"
+
+	"github.com/gorilla/mux"
+)
+
+// AddActivityHandler godoc
+// @Summary Add an attraction or restaurant to an itinerary day
+// @Security BearerAuth
+// @Tags Trips
+// @Accept json
+// @Produce json
+// @Param id path int true "Itinerary ID"
+// @Param activity body models.ActivityCreateRequest true "Activity"
+// @Success 201 {object} models.ItineraryActivity
+// @Failure 400 {string} string "Invalid activity"
+// @Failure 409 {string} string "Activity overlaps another one"
+// @Router /api/itineraries/{id}/activities [post]
+func (h *TripHandlers) AddActivityHandler(w http.ResponseWriter, r *http.Request) {
+	itineraryID, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+	var req models.ActivityCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	activity, err := h.TripPlanningService.AddActivity(itineraryID, req)
+	if err != nil {
+		writeActivityEditError(w, err, "Failed to add activity")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(activity)
+}
+
+// MoveActivityHandler godoc
+// @Summary Move an activity to another time or day of the same trip
+// @Description Omit itinerary_id to stay on the same day; omit the times to keep the clock time.
+// @Security BearerAuth
+// @Tags Trips
+// @Accept json
+// @Produce json
+// @Param id path int true "Activity ID"
+// @Param move body models.ActivityMoveRequest true "Target day and time"
+// @Success 200 {object} models.ItineraryActivity
+// @Failure 400 {string} string "Invalid move"
+// @Failure 409 {string} string "Activity overlaps another one"
+// @Router /api/activities/{id}/move [post]
+func (h *TripHandlers) MoveActivityHandler(w http.ResponseWriter, r *http.Request) {
+	activityID, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+	var req models.ActivityMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	activity, err := h.TripPlanningService.MoveActivity(activityID, req)
+	if err != nil {
+		writeActivityEditError(w, err, "Failed to move activity")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(activity)
+}
+
+// GetActivityAlternativesHandler godoc
+// @Summary Nearby replacements for an activity
+// @Description Closest attractions not yet in the trip, or closest other restaurants
+// @Security BearerAuth
+// @Tags Trips
+// @Produce json
+// @Param id path int true "Activity ID"
+// @Success 200 {array} models.ActivityAlternative
+// @Router /api/activities/{id}/alternatives [get]
+func (h *TripHandlers) GetActivityAlternativesHandler(w http.ResponseWriter, r *http.Request) {
+	activityID, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+	alternatives, err := h.TripPlanningService.GetActivityAlternatives(activityID)
+	if err != nil {
+		writeActivityEditError(w, err, "Failed to fetch alternatives")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(alternatives)
+}
+
+// ReplaceActivityHandler godoc
+// @Summary Swap an activity for one of its nearby alternatives
+// @Security BearerAuth
+// @Tags Trips
+// @Accept json
+// @Produce json
+// @Param id path int true "Activity ID"
+// @Param replacement body models.ActivityReplaceRequest true "Alternative to use"
+// @Success 200 {object} models.ItineraryActivity
+// @Failure 400 {string} string "Not an alternative"
+// @Router /api/activities/{id}/replace [post]
+func (h *TripHandlers) ReplaceActivityHandler(w http.ResponseWriter, r *http.Request) {
+	activityID, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+	var req models.ActivityReplaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	activity, err := h.TripPlanningService.ReplaceActivity(activityID, req)
+	if err != nil {
+		writeActivityEditError(w, err, "Failed to replace activity")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(activity)
+}
+
+// DeleteActivityHandler godoc
+// @Summary Remove an activity from the itinerary
+// @Security BearerAuth
+// @Tags Trips
+// @Param id path int true "Activity ID"
+// @Success 200 {string} string "Activity deleted"
+// @Router /api/activities/{id} [delete]
+func (h *TripHandlers) DeleteActivityHandler(w http.ResponseWriter, r *http.Request) {
+	activityID, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+	if err := h.TripPlanningService.DeleteActivity(activityID); err != nil {
+		writeActivityEditError(w, err, "Failed to delete activity")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Activity deleted"))
+}
+
+func writeActivityEditError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidActivityEdit):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, services.ErrActivityConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, services.ErrActivityNotFound), errors.Is(err, services.ErrTripNotFound):
+		http.Error(w, "Activity not found", http.StatusNotFound)
+	default:
+		slog.Error(message, "error", err)
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
+
+// RegenerateDayHandler godoc
+// @Summary Regenerate one day of a confirmed trip
+// @Description Replans the attractions and meals of a sightseeing day without touching the other days
+// @Security BearerAuth
+// @Tags Trips
+// @Produce json
+// @Param id path int true "Trip ID"
+// @Param day path int true "Day number"
+// @Success 200 {array} models.ItineraryActivity
+// @Failure 400 {string} string "Day cannot be regenerated"
+// @Router /api/trips/{id}/days/{day}/regenerate [post]
+func (h *TripHandlers) RegenerateDayHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tripID, _ := strconv.Atoi(vars["id"])
+	dayNumber, err := strconv.Atoi(vars["day"])
+	if err != nil || dayNumber <= 0 {
+		http.Error(w, "Invalid day number", http.StatusBadRequest)
+		return
+	}
+
+	activities, err := h.TripPlanningService.RegenerateDay(tripID, dayNumber)
+	if err != nil {
+		if errors.Is(err, services.ErrDayNotRegenerable) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to regenerate itinerary day", "trip_id", tripID, "day", dayNumber, "error", err)
+		http.Error(w, "Failed to regenerate day", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(activities)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"travel-planning/services"
+
+	"github.com/gorilla/mux"
+)
+
+// ExportItineraryICSHandler godoc
+// @Summary Export the itinerary as an iCalendar file
+// @Description Every activity becomes a VEVENT with its location and notes, timed in the destination timezone
+// @Security BearerAuth
+// @Tags Trips
+// @Produce text/calendar
+// @Param id path int true "Trip ID"
+// @Success 200 {file} file "itinerary.ics"
+// @Router /api/trips/{id}/itinerary.ics [get]
+func (h *TripHandlers) ExportItineraryICSHandler(w http.ResponseWriter, r *http.Request) {
+	tripID, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	calendar, err := h.TripPlanningService.ExportItineraryICS(tripID)
+	if err != nil {
+		slog.Error("Failed to export itinerary calendar", "trip_id", tripID, "error", err)
+		http.Error(w, "Failed to export itinerary", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="trip-%d.ics"`, tripID))
+	w.Write(calendar)
+}
+
+// ExportItineraryPDFHandler godoc
+// @Summary Download a printable trip booklet
+// @Description Cover page, per-day schedule with ratings, entry fees and backup alternatives, and a cost summary
+// @Security BearerAuth
+// @Tags Trips
+// @Produce application/pdf
+// @Param id path int true "Trip ID"
+// @Success 200 {file} file "itinerary.pdf"
+// @Failure 409 {string} string "Trip is not confirmed yet"
+// @Router /api/trips/{id}/itinerary.pdf [get]
+func (h *TripHandlers) ExportItineraryPDFHandler(w http.ResponseWriter, r *http.Request) {
+	tripID, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	booklet, err := h.TripPlanningService.ExportItineraryPDF(tripID)
+	if err != nil {
+		if errors.Is(err, services.ErrItineraryNotConfirmed) {
+			http.Error(w, "Trip is not confirmed yet", http.StatusConflict)
+			return
+		}
+		slog.Error("Failed to render trip booklet", "trip_id", tripID, "error", err)
+		http.Error(w, "Failed to render trip booklet", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="trip-%d.pdf"`, tripID))
+	w.Write(booklet)
+}
+
+// ExportItineraryGPXHandler godoc
+// @Summary Export the itinerary as GPX
+// @Description Waypoints for every located activity and one route per day, in itinerary order
+// @Security BearerAuth
+// @Tags Trips
+// @Produce application/gpx+xml
+// @Param id path int true "Trip ID"
+// @Success 200 {file} file "itinerary.gpx"
+// @Router /api/trips/{id}/itinerary.gpx [get]
+func (h *TripHandlers) ExportItineraryGPXHandler(w http.ResponseWriter, r *http.Request) {
+	tripID, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	gpx, err := h.TripPlanningService.ExportItineraryGPX(tripID)
+	if err != nil {
+		slog.Error("Failed to export itinerary GPX", "trip_id", tripID, "error", err)
+		http.Error(w, "Failed to export itinerary", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gpx+xml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="trip-%d.gpx"`, tripID))
+	w.Write(gpx)
+}
+
+// ExportItineraryKMLHandler godoc
+// @Summary Export the itinerary as KML
+// @Description One folder per day with a placemark per located activity and the day's route
+// @Security BearerAuth
+// @Tags Trips
+// @Produce application/vnd.google-earth.kml+xml
+// @Param id path int true "Trip ID"
+// @Success 200 {file} file "itinerary.kml"
+// @Router /api/trips/{id}/itinerary.kml [get]
+func (h *TripHandlers) ExportItineraryKMLHandler(w http.ResponseWriter, r *http.Request) {
+	tripID, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	kml, err := h.TripPlanningService.ExportItineraryKML(tripID)
+	if err != nil {
+		slog.Error("Failed to export itinerary KML", "trip_id", tripID, "error", err)
+		http.Error(w, "Failed to export itinerary", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.google-earth.kml+xml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="trip-%d.kml"`, tripID))
+	w.Write(kml)
+}
+
+// ExportItineraryGeoJSONHandler godoc
+// @Summary Trip activities as GeoJSON
+// @Description Located activities as a FeatureCollection, optionally limited to a city or bounding box
+// @Security BearerAuth
+// @Tags Trips
+// @Produce json
+// @Param id path int true "Trip ID"
+// @Param city_id query int false "City ID"
+// @Param bbox query string false "minLon,minLat,maxLon,maxLat"
+// @Success 200 {object} models.FeatureCollection
+// @Router /api/trips/{id}/itinerary.geojson [get]
+func (h *TripHandlers) ExportItineraryGeoJSONHandler(w http.ResponseWriter, r *http.Request) {
+	tripID, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	filter, err := parseResourceFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fc, err := h.TripPlanningService.ExportItineraryGeoJSON(tripID, filter)
+	if err != nil {
+		slog.Error("Failed to export itinerary GeoJSON", "trip_id", tripID, "error", err)
+		http.Error(w, "Failed to export itinerary", http.StatusInternalServerError)
+		return
+	}
+	writeGeoJSON(w, fc)
+}
@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"travel-planning/models"
 	"travel-planning/services"
 )
 
@@ -44,134 +46,212 @@ func (h *ResourceHandlers) GetAllCountriesHandler(w http.ResponseWriter, r *http
 }
 
 // GetAllCitiesHandler godoc
-// @Summary Get all cities
+// @Summary List cities
 // @Tags Resources
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {array} models.City
+// @Param country_id query int false "Country ID"
+// @Param bbox query string false "minLon,minLat,maxLon,maxLat"
+// @Param sort query string false "id or name, prefix with - for descending"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} models.Page[models.City]
 // @Router /api/cities [get]
 func (h *ResourceHandlers) GetAllCitiesHandler(w http.ResponseWriter, r *http.Request) {
 	l := slog.With("endpoint", "GetAllCities", "method", r.Method)
 
-	cities, err := h.ResourceService.GetAllCities()
+	filter, err := parseResourceFilter(r)
 	if err != nil {
-		l.Error("Service error", "error", err)
-		http.Error(w, "Error fetching cities", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	l.Debug("Cities fetched successfully", "count", len(cities))
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(cities); err != nil {
-		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	page, err := h.ResourceService.GetAllCities(filter)
+	if err != nil {
+		writeListError(w, l, err, "Error fetching cities")
+		return
 	}
 
+	l.Debug("Cities fetched successfully", "count", len(page.Data))
+	writePage(w, page)
 }
 
 // GetAllAttractionssHandler godoc
-// @Summary Get all attractions
+// @Summary List attractions
+// @Description Add format=geojson (or Accept: application/geo+json) for a GeoJSON FeatureCollection of the page
 // @Tags Resources
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {array} models.Attraction
+// @Param city_id query int false "City ID"
+// @Param country_id query int false "Country ID"
+// @Param bbox query string false "minLon,minLat,maxLon,maxLat"
+// @Param category query string false "Category"
+// @Param min_rating query number false "Minimum rating"
+// @Param max_price query number false "Maximum entry fee"
+// @Param sort query string false "id, name, rating or entry_fee, prefix with - for descending"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param format query string false "json or geojson"
+// @Success 200 {object} models.Page[models.Attraction]
 // @Router /api/attractions [get]
 func (h *ResourceHandlers) GetAllAttractionssHandler(w http.ResponseWriter, r *http.Request) {
 	l := slog.With("endpoint", "GetAllAttractions", "method", r.Method)
 
-	attractions, err := h.ResourceService.GetAllAttractions()
+	filter, err := parseResourceFilter(r)
 	if err != nil {
-		l.Error("Service error", "error", err)
-		http.Error(w, "Error fetching attractions", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	l.Debug("Attractions fetched successfully", "count", len(attractions))
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(attractions); err != nil {
-		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	page, err := h.ResourceService.GetAllAttractions(filter)
+	if err != nil {
+		writeListError(w, l, err, "Error fetching attractions")
+		return
 	}
 
+	l.Debug("Attractions fetched successfully", "count", len(page.Data))
+	if wantsGeoJSON(r) {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+		writeGeoJSON(w, services.AttractionsGeoJSON(page.Data))
+		return
+	}
+	writePage(w, page)
 }
 
 // GetAllHotelsHandler godoc
-// @Summary Get all hotels
+// @Summary List hotels
+// @Description Add format=geojson (or Accept: application/geo+json) for a GeoJSON FeatureCollection of the page
 // @Tags Resources
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {array} models.Hotel
+// @Param city_id query int false "City ID"
+// @Param country_id query int false "Country ID"
+// @Param bbox query string false "minLon,minLat,maxLon,maxLat"
+// @Param min_rating query number false "Minimum rating"
+// @Param max_price query number false "Maximum price per night"
+// @Param stars query int false "Stars"
+// @Param sort query string false "id, name, rating, price or stars, prefix with - for descending"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param format query string false "json or geojson"
+// @Success 200 {object} models.Page[models.Hotel]
 // @Router /api/hotels [get]
 func (h *ResourceHandlers) GetAllHotelsHandler(w http.ResponseWriter, r *http.Request) {
 	l := slog.With("endpoint", "GetAllHotels", "method", r.Method)
 
-	hotels, err := h.ResourceService.GetAllHotels()
+	filter, err := parseResourceFilter(r)
 	if err != nil {
-		l.Error("Service error", "error", err)
-		http.Error(w, "Error fetching hotels", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	l.Debug("Hotels fetched successfully", "count", len(hotels))
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(hotels); err != nil {
-		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	page, err := h.ResourceService.GetAllHotels(filter)
+	if err != nil {
+		writeListError(w, l, err, "Error fetching hotels")
+		return
 	}
 
+	l.Debug("Hotels fetched successfully", "count", len(page.Data))
+	if wantsGeoJSON(r) {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+		writeGeoJSON(w, services.HotelsGeoJSON(page.Data))
+		return
+	}
+	writePage(w, page)
 }
 
 // GetAllRestaurantssHandler godoc
-// @Summary Get all restaurants
+// @Summary List restaurants
+// @Description Add format=geojson (or Accept: application/geo+json) for a GeoJSON FeatureCollection of the page
 // @Tags Resources
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {array} models.Restaurant
+// @Param city_id query int false "City ID"
+// @Param country_id query int false "Country ID"
+// @Param bbox query string false "minLon,minLat,maxLon,maxLat"
+// @Param cuisine query string false "Cuisine"
+// @Param price_range query string false "Price range"
+// @Param min_rating query number false "Minimum rating"
+// @Param sort query string false "id, name or rating, prefix with - for descending"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param format query string false "json or geojson"
+// @Success 200 {object} models.Page[models.Restaurant]
 // @Router /api/restaurants [get]
 func (h *ResourceHandlers) GetAllRestaurantssHandler(w http.ResponseWriter, r *http.Request) {
 	l := slog.With("endpoint", "GetAllRestaurants", "method", r.Method)
 
-	restaurants, err := h.ResourceService.GetAllRestaurants()
+	filter, err := parseResourceFilter(r)
 	if err != nil {
-		l.Error("Service error", "error", err)
-		http.Error(w, "Error fetching restaurants", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	l.Debug("Restaurants fetched successfully", "count", len(restaurants))
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(restaurants); err != nil {
-		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	page, err := h.ResourceService.GetAllRestaurants(filter)
+	if err != nil {
+		writeListError(w, l, err, "Error fetching restaurants")
+		return
 	}
 
+	l.Debug("Restaurants fetched successfully", "count", len(page.Data))
+	if wantsGeoJSON(r) {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+		writeGeoJSON(w, services.RestaurantsGeoJSON(page.Data))
+		return
+	}
+	writePage(w, page)
 }
 
 // GetAllFlightsHandler godoc
-// @Summary Get all flights
+// @Summary List flights
 // @Tags Resources
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {array} models.Flight
+// @Param city_id query int false "Departure or arrival city ID"
+// @Param from_city_id query int false "Departure city ID"
+// @Param to_city_id query int false "Arrival city ID"
+// @Param country_id query int false "Arrival country ID"
+// @Param max_price query number false "Maximum price"
+// @Param sort query string false "id, price or duration, prefix with - for descending"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} models.Page[models.Flight]
 // @Router /api/flights [get]
 func (h *ResourceHandlers) GetAllFlightsHandler(w http.ResponseWriter, r *http.Request) {
 	l := slog.With("endpoint", "GetAllFlights", "method", r.Method)
 
-	flights, err := h.ResourceService.GetAllFlights()
+	filter, err := parseResourceFilter(r)
 	if err != nil {
-		l.Error("Service error", "error", err)
-		http.Error(w, "Error fetching flights", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	l.Debug("Flights fetched successfully", "count", len(flights))
-	w.Header().Set("Content-Type", "application/json")
+	page, err := h.ResourceService.GetAllFlights(filter)
+	if err != nil {
+		writeListError(w, l, err, "Error fetching flights")
+		return
+	}
+
+	l.Debug("Flights fetched successfully", "count", len(page.Data))
+	writePage(w, page)
+}
 
-	if err := json.NewEncoder(w).Encode(flights); err != nil {
+func writePage[T any](w http.ResponseWriter, page models.Page[T]) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(page); err != nil {
 		http.Error(w, "Error encoding response", http.StatusInternalServerError)
 	}
 }
 
+func writeListError(w http.ResponseWriter, l *slog.Logger, err error, message string) {
+	if errors.Is(err, services.ErrInvalidListQuery) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	l.Error("Service error", "error", err)
+	http.Error(w, message, http.StatusInternalServerError)
+}
+
 // GetVisitedEntitiesHandler godoc
 // @Summary Get entities (hotels, attractions, restaurants) visited by the user
 // @Description Returns a list of entities that appear in the user's completed trips
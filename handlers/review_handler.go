@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -23,13 +24,18 @@ func NewReviewHandlers(reviewService *services.ReviewService) *ReviewHandlers {
 
 // CreateReviewHandler godoc
 // @Summary Create a new review
-// @Description Submit a review for a hotel, attraction, or restaurant
+// @Description Submit a review for a hotel, attraction, or restaurant from one of the user's completed trips.
+// @Description Such reviews are marked as verified. Each place can be reviewed once per user.
+// @Description Reviews flagged for profanity, links or excessive length are held as pending for moderation.
 // @Security BearerAuth
 // @Tags Reviews
 // @Accept json
 // @Produce json
 // @Param review body models.CreateReviewRequest true "Review details"
-// @Success 201 {object} map[string]interface{} "review_id"
+// @Success 201 {object} map[string]interface{} "review_id, status and flags"
+// @Failure 400 {string} string "Invalid review"
+// @Failure 403 {string} string "Place not visited on a completed trip"
+// @Failure 409 {string} string "Place already reviewed"
 // @Router /api/reviews [post]
 func (h *ReviewHandlers) CreateReviewHandler(w http.ResponseWriter, r *http.Request) {
 	userIDStr := r.Header.Get("X-User-ID")
@@ -51,18 +57,22 @@ func (h *ReviewHandlers) CreateReviewHandler(w http.ResponseWriter, r *http.Requ
 
 	l.Info("Attempting to create review", "entity_type", req.EntityType, "entity_id", req.EntityID)
 
-	reviewID, err := h.ReviewService.CreateReview(userID, req)
+	review, err := h.ReviewService.CreateReview(userID, req)
 	if err != nil {
-		l.Error("Failed to create review", "error", err)
-		http.Error(w, "Failed to create review.", http.StatusInternalServerError)
+		if !writeReviewError(w, err) {
+			l.Error("Failed to create review", "error", err)
+			http.Error(w, "Failed to create review.", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	l.Info("Review created successfully", "review_id", reviewID)
+	l.Info("Review created successfully", "review_id", review.ReviewID, "status", review.Status)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"review_id": reviewID,
+		"review_id": review.ReviewID,
+		"status":    review.Status,
+		"flags":     review.Flags,
 	})
 
 }
@@ -125,3 +135,241 @@ func (h *ReviewHandlers) DeleteReviewHandler(w http.ResponseWriter, r *http.Requ
 	slog.Info("Review deleted", "review_id", reviewID, "user_id", userID)
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// placeTypes maps the collection in /api/{collection}/{id}/reviews to a review entity type.
+var placeTypes = map[string]string{
+	"hotels":      "hotel",
+	"attractions": "attraction",
+	"restaurants": "restaurant",
+}
+
+// GetPlaceReviewsHandler godoc
+// @Summary List reviews of a hotel, attraction or restaurant
+// @Description Paged reviews with a summary (count, average, histogram of 1-5 stars) of all of them
+// @Security BearerAuth
+// @Tags Reviews
+// @Produce json
+// @Param collection path string true "hotels, attractions or restaurants"
+// @Param id path int true "Place ID"
+// @Param sort query string false "newest (default), highest or lowest"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} models.PlaceReviewPage
+// @Router /api/{collection}/{id}/reviews [get]
+func (h *ReviewHandlers) GetPlaceReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entityType := placeTypes[vars["collection"]]
+	entityID, err := strconv.Atoi(vars["id"])
+	if entityType == "" || err != nil || entityID <= 0 {
+		http.Error(w, "Invalid place", http.StatusBadRequest)
+		return
+	}
+	l := slog.With("endpoint", "GetPlaceReviews", "entity_type", entityType, "entity_id", entityID)
+
+	query := r.URL.Query()
+	params := models.ListParams{Cursor: query.Get("cursor"), Sort: query.Get("sort")}
+	if raw := query.Get("limit"); raw != "" {
+		if params.Limit, err = strconv.Atoi(raw); err != nil || params.Limit <= 0 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	reviews, err := h.ReviewService.GetPlaceReviews(entityType, entityID, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPlaceNotFound):
+			http.Error(w, "Place not found", http.StatusNotFound)
+		case errors.Is(err, services.ErrInvalidListQuery):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			l.Error("Failed to fetch place reviews", "error", err)
+			http.Error(w, "Error fetching reviews", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	l.Debug("Fetched reviews for place", "count", len(reviews.Data))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(reviews); err != nil {
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	}
+}
+
+// UpdateReviewHandler godoc
+// @Summary Edit a review
+// @Description Change the rating and comment of your review. The previous version is kept in its history
+// @Description and the new text is checked again, so a flagged edit goes back to the moderation queue.
+// @Security BearerAuth
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param review body models.UpdateReviewRequest true "New rating and comment"
+// @Success 200 {object} models.Review
+// @Failure 404 {string} string "Review not found"
+// @Router /api/reviews/{id} [put]
+func (h *ReviewHandlers) UpdateReviewHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(r.Header.Get("X-User-ID"))
+	if err != nil || userID <= 0 {
+		http.Error(w, "Authentication error: Invalid User ID", http.StatusUnauthorized)
+		return
+	}
+	reviewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || reviewID <= 0 {
+		http.Error(w, "Invalid review ID", http.StatusBadRequest)
+		return
+	}
+	l := slog.With("user_id", userID, "review_id", reviewID, "path", r.URL.Path, "method", r.Method)
+
+	var req models.UpdateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	review, err := h.ReviewService.UpdateReview(userID, reviewID, req)
+	if err != nil {
+		if !writeReviewError(w, err) {
+			l.Error("Failed to update review", "error", err)
+			http.Error(w, "Failed to update review", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(review)
+}
+
+// GetReviewHistoryHandler godoc
+// @Summary Edit history of a review
+// @Description Previous versions of a review, most recent first. Visible to its author and to admins.
+// @Security BearerAuth
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 200 {array} models.ReviewEdit
+// @Failure 404 {string} string "Review not found"
+// @Router /api/reviews/{id}/history [get]
+func (h *ReviewHandlers) GetReviewHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(r.Header.Get("X-User-ID"))
+	if err != nil || userID <= 0 {
+		http.Error(w, "Authentication error: Invalid User ID", http.StatusUnauthorized)
+		return
+	}
+	reviewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || reviewID <= 0 {
+		http.Error(w, "Invalid review ID", http.StatusBadRequest)
+		return
+	}
+
+	edits, err := h.ReviewService.GetReviewHistory(userID, reviewID)
+	if err != nil {
+		if !writeReviewError(w, err) {
+			slog.Error("Failed to fetch review history", "review_id", reviewID, "error", err)
+			http.Error(w, "Error fetching review history", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(edits)
+}
+
+// GetModerationQueueHandler godoc
+// @Summary Review moderation queue
+// @Description Reviews in one moderation state (pending by default), oldest first. Admin only.
+// @Security BearerAuth
+// @Tags Reviews
+// @Produce json
+// @Param status query string false "pending (default), approved or rejected"
+// @Param sort query string false "oldest (default) or newest"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} models.Page[models.Review]
+// @Failure 403 {string} string "Admin role required"
+// @Router /api/admin/reviews [get]
+func (h *ReviewHandlers) GetModerationQueueHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := models.ListParams{Cursor: query.Get("cursor"), Sort: query.Get("sort")}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		params.Limit = limit
+	}
+
+	page, err := h.ReviewService.GetModerationQueue(query.Get("status"), params)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidListQuery) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to fetch moderation queue", "error", err)
+		http.Error(w, "Error fetching reviews", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// ModerateReviewHandler godoc
+// @Summary Approve or reject a review
+// @Description Approved reviews are public and count towards the place rating; rejected ones are hidden. Admin only.
+// @Security BearerAuth
+// @Tags Reviews
+// @Accept json
+// @Param id path int true "Review ID"
+// @Param decision path string true "approve or reject"
+// @Param body body models.ModerationRequest false "Optional note for the author"
+// @Success 204 "No Content"
+// @Failure 403 {string} string "Admin role required"
+// @Failure 404 {string} string "Review not found"
+// @Router /api/admin/reviews/{id}/{decision} [post]
+func (h *ReviewHandlers) ModerateReviewHandler(w http.ResponseWriter, r *http.Request) {
+	moderatorID, _ := strconv.Atoi(r.Header.Get("X-User-ID"))
+	vars := mux.Vars(r)
+	reviewID, err := strconv.Atoi(vars["id"])
+	if err != nil || reviewID <= 0 {
+		http.Error(w, "Invalid review ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ModerationRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	err = h.ReviewService.ModerateReview(moderatorID, reviewID, vars["decision"] == "approve", req.Note)
+	if err != nil {
+		if !writeReviewError(w, err) {
+			slog.Error("Failed to moderate review", "review_id", reviewID, "error", err)
+			http.Error(w, "Failed to moderate review", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeReviewError maps the review service errors to HTTP statuses and reports whether it wrote a response.
+func writeReviewError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, services.ErrInvalidReview):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, services.ErrPlaceNotVisited):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, services.ErrReviewNotFound):
+		http.Error(w, "Review not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrDuplicateReview):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		return false
+	}
+	return true
+}
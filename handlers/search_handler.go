@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"travel-planning/models"
+	"travel-planning/services"
+)
+
+// SearchPlacesHandler godoc
+// @Summary Search places
+// @Description Full-text and fuzzy search over cities, attractions, hotels and restaurants, ranked across types.
+// @Description "sushi near Shibuya" or "museum in Paris" narrows results around the named place.
+// @Tags Resources
+// @Security BearerAuth
+// @Produce json
+// @Param q query string true "Search text"
+// @Param types query string false "Comma-separated subset of city,attraction,hotel,restaurant"
+// @Param limit query int false "Maximum results (default 20, max 50)"
+// @Success 200 {object} models.SearchResponse
+// @Router /api/search [get]
+func (h *ResourceHandlers) SearchPlacesHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	l := slog.With("endpoint", "SearchPlaces", "method", r.Method, "q", query.Get("q"))
+
+	limit, err := parseSearchLimit(query.Get("limit"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.ResourceService.SearchPlaces(query.Get("q"), parseTypes(query.Get("types")), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		l.Error("Service error", "error", err)
+		http.Error(w, "Error searching places", http.StatusInternalServerError)
+		return
+	}
+
+	l.Debug("Search completed", "count", len(resp.Results))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	}
+}
+
+// NearbyPlacesHandler godoc
+// @Summary Places near a point
+// @Description Attractions, hotels and restaurants within radius_km of lat/lon, closest first
+// @Tags Resources
+// @Security BearerAuth
+// @Produce json
+// @Param lat query number true "Latitude"
+// @Param lon query number true "Longitude"
+// @Param radius_km query number false "Radius in km (default 1, max 50)"
+// @Param types query string false "Comma-separated subset of attraction,hotel,restaurant"
+// @Param limit query int false "Maximum results (default 50, max 200)"
+// @Success 200 {array} models.NearbyPlace
+// @Router /api/nearby [get]
+func (h *ResourceHandlers) NearbyPlacesHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	l := slog.With("endpoint", "NearbyPlaces", "method", r.Method)
+
+	var q models.NearbyQuery
+	coords := []struct {
+		name     string
+		dest     *float64
+		required bool
+	}{
+		{"lat", &q.Latitude, true},
+		{"lon", &q.Longitude, true},
+		{"radius_km", &q.RadiusKm, false},
+	}
+	for _, c := range coords {
+		raw := query.Get(c.name)
+		if raw == "" {
+			if c.required {
+				http.Error(w, fmt.Sprintf("%s is required", c.name), http.StatusBadRequest)
+				return
+			}
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			http.Error(w, fmt.Sprintf("%s must be a number", c.name), http.StatusBadRequest)
+			return
+		}
+		*c.dest = v
+	}
+
+	limit, err := parseSearchLimit(query.Get("limit"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	q.Limit = limit
+	q.Types = parseTypes(query.Get("types"))
+
+	places, err := h.ResourceService.NearbyPlaces(q)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		l.Error("Service error", "error", err)
+		http.Error(w, "Error searching nearby places", http.StatusInternalServerError)
+		return
+	}
+
+	l.Debug("Nearby places fetched", "count", len(places))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(places); err != nil {
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	}
+}
+
+// parseTypes splits a comma-separated ?types= value.
+func parseTypes(raw string) []string {
+	var types []string
+	for _, t := range strings.Split(raw, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func parseSearchLimit(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		return 0, errors.New("limit must be a positive integer")
+	}
+	return v, nil
+}
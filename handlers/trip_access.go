@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"travel-planning/models"
+	"travel-planning/services"
+
+	"github.com/gorilla/mux"
+)
+
+// RequireTripRole guards a /api/trips/{id}/... route: the wrapped handler only runs for
+// users with at least the required role on the trip, and sees that role in X-Trip-Role.
+func (h *TripHandlers) RequireTripRole(required string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(r.Header.Get("X-User-ID"))
+		if err != nil || userID <= 0 {
+			http.Error(w, "Authentication error", http.StatusUnauthorized)
+			return
+		}
+		tripID, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil || tripID <= 0 {
+			http.Error(w, "Invalid Trip ID", http.StatusBadRequest)
+			return
+		}
+
+		role, err := h.TripPlanningService.AuthorizeTrip(tripID, userID, required)
+		if !writeTripAccessError(w, err) {
+			return
+		}
+
+		r.Header.Set("X-Trip-Role", role)
+		next(w, r)
+	}
+}
+
+// RequireItineraryRole guards a /api/itineraries/{id}/... route by the trip the day belongs to.
+func (h *TripHandlers) RequireItineraryRole(required string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(r.Header.Get("X-User-ID"))
+		if err != nil || userID <= 0 {
+			http.Error(w, "Authentication error", http.StatusUnauthorized)
+			return
+		}
+		itineraryID, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil || itineraryID <= 0 {
+			http.Error(w, "Invalid Itinerary ID format", http.StatusBadRequest)
+			return
+		}
+
+		role, err := h.TripPlanningService.AuthorizeItinerary(itineraryID, userID, required)
+		if !writeTripAccessError(w, err) {
+			return
+		}
+
+		r.Header.Set("X-Trip-Role", role)
+		next(w, r)
+	}
+}
+
+// RequireActivityRole guards an /api/activities/{id} route by the trip the activity belongs to.
+func (h *TripHandlers) RequireActivityRole(required string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(r.Header.Get("X-User-ID"))
+		if err != nil || userID <= 0 {
+			http.Error(w, "Authentication error", http.StatusUnauthorized)
+			return
+		}
+		activityID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil || activityID <= 0 {
+			http.Error(w, "Invalid Activity ID", http.StatusBadRequest)
+			return
+		}
+
+		role, err := h.TripPlanningService.AuthorizeActivity(activityID, userID, required)
+		if !writeTripAccessError(w, err) {
+			return
+		}
+
+		r.Header.Set("X-Trip-Role", role)
+		next(w, r)
+	}
+}
+
+// writeTripAccessError reports whether access was granted, answering the request otherwise.
+func writeTripAccessError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, services.ErrTripNotFound):
+		http.Error(w, "Trip not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrTripForbidden):
+		http.Error(w, "You do not have permission to do this on this trip", http.StatusForbidden)
+	default:
+		slog.Error("Failed to check trip access", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+	return false
+}
+
+// GetTripMembersHandler godoc
+// @Summary List trip members
+// @Description Users the trip is shared with, and their roles
+// @Security BearerAuth
+// @Tags Trips
+// @Param id path int true "Trip ID"
+// @Produce json
+// @Success 200 {array} models.TripMember
+// @Router /api/trips/{id}/members [get]
+func (h *TripHandlers) GetTripMembersHandler(w http.ResponseWriter, r *http.Request) {
+	tripID, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	members, err := h.TripPlanningService.GetTripMembers(tripID)
+	if err != nil {
+		slog.Error("Failed to fetch trip members", "trip_id", tripID, "error", err)
+		http.Error(w, "Error fetching trip members", http.StatusInternalServerError)
+		return
+	}
+	if members == nil {
+		members = []models.TripMember{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(members)
+}
+
+// InviteTripMemberHandler godoc
+// @Summary Share a trip (owner only)
+// @Description Invite a registered user by email as editor or viewer; inviting an existing member changes their role
+// @Security BearerAuth
+// @Tags Trips
+// @Accept json
+// @Produce json
+// @Param id path int true "Trip ID"
+// @Param invite body models.TripInviteRequest true "Invite"
+// @Success 201 {object} map[string]interface{} "user_id"
+// @Failure 400 {string} string "Invalid role or unknown user"
+// @Router /api/trips/{id}/members [post]
+func (h *TripHandlers) InviteTripMemberHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := strconv.Atoi(r.Header.Get("X-User-ID"))
+	tripID, _ := strconv.Atoi(mux.Vars(r)["id"])
+	l := slog.With("user_id", userID, "trip_id", tripID)
+
+	var req models.TripInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	memberID, err := h.TripPlanningService.InviteTripMember(tripID, userID, req)
+	if errors.Is(err, services.ErrInvalidTripRole) || errors.Is(err, services.ErrInviteeNotFound) {
+		l.Warn("Rejected trip invite", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		l.Error("Failed to invite trip member", "error", err)
+		http.Error(w, "Error inviting trip member", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{"user_id": memberID})
+}
+
+// RemoveTripMemberHandler godoc
+// @Summary Remove a trip member
+// @Description The owner can remove any member; members can remove themselves to leave the trip
+// @Security BearerAuth
+// @Tags Trips
+// @Param id path int true "Trip ID"
+// @Param userId path int true "Member user ID"
+// @Success 204
+// @Router /api/trips/{id}/members/{userId} [delete]
+func (h *TripHandlers) RemoveTripMemberHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := strconv.Atoi(r.Header.Get("X-User-ID"))
+	vars := mux.Vars(r)
+	tripID, _ := strconv.Atoi(vars["id"])
+	memberID, err := strconv.Atoi(vars["userId"])
+	if err != nil || memberID <= 0 {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.TripPlanningService.RemoveTripMember(tripID, userID, memberID, r.Header.Get("X-Trip-Role"))
+	if errors.Is(err, services.ErrTripForbidden) {
+		http.Error(w, "Only the owner can remove other members", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		slog.Warn("Failed to remove trip member", "trip_id", tripID, "member_id", memberID, "error", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 	"travel-planning/models"
 	"travel-planning/services"
 
@@ -57,6 +59,10 @@ func (h *TripHandlers) CreateTripHandler(w http.ResponseWriter, r *http.Request)
 
 	l.Info("Starting trip planning", "trip_name", req.Name)
 	tripID, err := h.TripPlanningService.PlanTrip(userID, req)
+	if errors.Is(err, services.ErrInvalidTripLegs) || errors.Is(err, services.ErrInvalidTravelers) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		l.Error("Trip Planning Failed", "error", err)
 		http.Error(w, fmt.Sprintf("Failed to process trip plan: %v", err), http.StatusInternalServerError)
@@ -75,6 +81,7 @@ func (h *TripHandlers) CreateTripHandler(w http.ResponseWriter, r *http.Request)
 // @Param id path int true "Trip ID"
 // @Produce json
 // @Success 200 {array} models.TripOption
+// @Failure 409 {string} string "Trip options are already being generated"
 // @Router /api/trips/{id}/generate-options [post]
 func (h *TripHandlers) GenerateTripOptions(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -86,7 +93,12 @@ func (h *TripHandlers) GenerateTripOptions(w http.ResponseWriter, r *http.Reques
 	l := slog.With("trip_id", tripID)
 	l.Info("Generating trip options (Budget tiers)")
 
-	options, err := h.TripPlanningService.GenerateOptions(tripID)
+	options, err := h.TripPlanningService.GenerateOptionsNow(tripID)
+	if errors.Is(err, services.ErrInvalidPlanningTransition) {
+		l.Warn("Trip options are already being generated", "error", err)
+		http.Error(w, "Trip options are already being generated", http.StatusConflict)
+		return
+	}
 	if err != nil {
 		l.Error("Failed to generate trip options", "error", err)
 		http.Error(w, "Failed to generate plan", http.StatusInternalServerError)
@@ -181,14 +193,103 @@ func (h *TripHandlers) GetActivitiesHandler(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(activities)
 }
 
+// GetActivityHandler godoc
+// @Summary Get an itinerary activity
+// @Security BearerAuth
+// @Tags Trips
+// @Param id path int true "Activity ID"
+// @Produce json
+// @Success 200 {object} models.ItineraryActivity
+// @Failure 404 {string} string "Activity not found"
+// @Router /api/activities/{id} [get]
+func (h *TripHandlers) GetActivityHandler(w http.ResponseWriter, r *http.Request) {
+	activityID, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+	activity, err := h.TripPlanningService.GetActivity(activityID)
+	if err != nil {
+		slog.Error("DB Error fetching activity", "activity_id", activityID, "error", err)
+		http.Error(w, "Error fetching activity", http.StatusInternalServerError)
+		return
+	}
+	if activity == nil {
+		http.Error(w, "Activity not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(activity)
+}
+
+// GetTripOptionsHandler godoc
+// @Summary Get generated trip options
+// @Description Fetch the options stored by the last generation for this trip
+// @Security BearerAuth
+// @Tags Trips
+// @Param id path int true "Trip ID"
+// @Produce json
+// @Success 200 {array} models.TripOption
+// @Router /api/trips/{id}/options [get]
+func (h *TripHandlers) GetTripOptionsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tripID, err := strconv.Atoi(vars["id"])
+	if err != nil || tripID <= 0 {
+		http.Error(w, "Invalid Trip ID", http.StatusBadRequest)
+		return
+	}
+	l := slog.With("trip_id", tripID)
+
+	options, err := h.TripPlanningService.GetTripOptions(tripID)
+	if err != nil {
+		l.Error("Failed to fetch trip options", "error", err)
+		http.Error(w, "Error fetching trip options", http.StatusInternalServerError)
+		return
+	}
+	if options == nil {
+		options = []models.TripOption{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(options)
+}
+
+// GetTripLegsHandler godoc
+// @Summary Get trip legs
+// @Description Fetch the cities of a trip in travel order with their arrival and departure dates
+// @Security BearerAuth
+// @Tags Trips
+// @Param id path int true "Trip ID"
+// @Produce json
+// @Success 200 {array} models.TripLeg
+// @Router /api/trips/{id}/legs [get]
+func (h *TripHandlers) GetTripLegsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tripID, err := strconv.Atoi(vars["id"])
+	if err != nil || tripID <= 0 {
+		http.Error(w, "Invalid Trip ID", http.StatusBadRequest)
+		return
+	}
+	l := slog.With("trip_id", tripID)
+
+	legs, err := h.TripPlanningService.GetTripLegs(tripID)
+	if err != nil {
+		l.Error("Failed to fetch trip legs", "error", err)
+		http.Error(w, "Error fetching trip legs", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(legs)
+}
+
 // SelectTripOption godoc
 // @Summary Finalize trip selection
-// @Description Confirm the chosen travel tier and logistics IDs to finalize the plan
+// @Description Confirm one of the generated options of this trip to finalize the plan
 // @Security BearerAuth
 // @Tags Trips
 // @Param id path int true "Trip ID"
-// @Param selection body object true "Selected tier and entity IDs"
+// @Param selection body object true "Selected option ID"
 // @Success 200 {string} string "Trip finalized successfully"
+// @Failure 409 {string} string "Trip is already finalized"
 // @Router /api/trips/{id}/select-option [post]
 func (h *TripHandlers) SelectTripOption(w http.ResponseWriter, r *http.Request) {
 	userIDStr := r.Header.Get("X-User-ID")
@@ -198,26 +299,30 @@ func (h *TripHandlers) SelectTripOption(w http.ResponseWriter, r *http.Request)
 	l := slog.With("user_id", userID, "trip_id", tripID)
 
 	var req struct {
-		Tier             string `json:"tier"`
-		HotelID          int    `json:"hotel_id"`
-		OutboundFlightID int    `json:"outbound_flight_id"`
-		InboundFlightID  int    `json:"inbound_flight_id"`
+		OptionID int `json:"option_id"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		l.Warn("Invalid selection body", "error", err)
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	if req.OptionID <= 0 {
+		http.Error(w, "option_id is required", http.StatusBadRequest)
+		return
+	}
 
-	l.Info("Finalizing trip selection", "tier", req.Tier)
-	err := h.TripPlanningService.FinalizeTripPlan(
-		tripID,
-		req.Tier,
-		req.HotelID,
-		req.OutboundFlightID,
-		req.InboundFlightID)
+	l.Info("Finalizing trip selection", "option_id", req.OptionID)
+	err := h.TripPlanningService.SelectTripOption(tripID, req.OptionID)
+	if errors.Is(err, services.ErrTripOptionNotFound) {
+		http.Error(w, "Option not found for this trip", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, services.ErrTripAlreadyFinalized) {
+		http.Error(w, "Trip is already finalized", http.StatusConflict)
+		return
+	}
 	if err != nil {
-		l.Error("Failed to finalize trip plan", "tier", req.Tier, "error", err)
+		l.Error("Failed to finalize trip plan", "option_id", req.OptionID, "error", err)
 		http.Error(w, "Failed to finalize trip: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -253,7 +358,11 @@ func (h *TripHandlers) DeleteTripHandler(w http.ResponseWriter, r *http.Request)
 
 	if err := h.TripPlanningService.DeleteUserTrip(tripID, userID); err != nil {
 		l.Error("Failed to delete trip", "error", err)
-		http.Error(w, err.Error(), http.StatusNotFound)
+		if errors.Is(err, services.ErrTripForbidden) {
+			http.Error(w, "Only the owner can delete a trip", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Trip not found", http.StatusNotFound)
 		return
 	}
 
@@ -337,6 +446,10 @@ func (h *TripHandlers) CompleteTripHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	err = h.TripPlanningService.UpdateTripStatus(tripID, userID, "Completed")
+	if errors.Is(err, services.ErrTripNotFound) || errors.Is(err, services.ErrTripForbidden) {
+		writeTripAccessError(w, err)
+		return
+	}
 	if err != nil {
 		slog.Error("Database update failed", "trip_id", tripID, "error", err)
 		http.Error(w, "Failed to update trip status", http.StatusInternalServerError)
@@ -347,3 +460,107 @@ func (h *TripHandlers) CompleteTripHandler(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"status": "success", "message": "Trip marked as completed"})
 }
+
+// GetScoringWeightsHandler godoc
+// @Summary Get attraction scoring weights
+// @Description Weights used to rank attractions by preferred category, rating, entry fee and distance from the hotel
+// @Security BearerAuth
+// @Tags Trips
+// @Produce json
+// @Success 200 {object} services.AttractionScoringWeights
+// @Router /api/trips/scoring-weights [get]
+func (h *TripHandlers) GetScoringWeightsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.TripPlanningService.GetScoringWeights())
+}
+
+// TripEventsHandler godoc
+// @Summary Stream trip planning status
+// @Description Server-Sent Events stream of planning job transitions (queued, generating, options_ready, failed).
+// @Description EventSource clients that cannot send the Authorization header pass the token as access_token.
+// @Security BearerAuth
+// @Tags Trips
+// @Param id path int true "Trip ID"
+// @Param access_token query string false "Access token, when the Authorization header cannot be set"
+// @Produce text/event-stream
+// @Success 200 {object} models.PlanningEvent
+// @Router /api/trips/{id}/events [get]
+func (h *TripHandlers) TripEventsHandler(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.Header.Get("X-User-ID")
+	userID, err := strconv.Atoi(userIDStr)
+	vars := mux.Vars(r)
+	tripID, errT := strconv.Atoi(vars["id"])
+	l := slog.With("user_id", userID, "trip_id", tripID)
+
+	if err != nil || userID <= 0 {
+		http.Error(w, "Authentication error", http.StatusUnauthorized)
+		return
+	}
+	if errT != nil || tripID <= 0 {
+		http.Error(w, "Invalid trip ID", http.StatusBadRequest)
+		return
+	}
+
+	trip, err := h.TripPlanningService.GetTripByID(tripID)
+	if err != nil {
+		http.Error(w, "Trip not found", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := h.TripPlanningService.SubscribePlanningEvents(tripID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	l.Info("Client subscribed to planning events")
+
+	var last models.PlanningEvent
+	send := func(event models.PlanningEvent) {
+		if event.Status == last.Status && event.Reason == last.Reason {
+			return
+		}
+		last = event
+		data, _ := json.Marshal(event)
+		fmt.Fprintf(w, "event: planning\ndata: %s\n\n", data)
+		flusher.Flush()
+	}
+
+	send(models.PlanningEvent{
+		TripID:    trip.TripID,
+		Status:    trip.PlanningStatus,
+		Reason:    trip.PlanningError,
+		UpdatedAt: trip.PlanningUpdatedAt,
+	})
+
+	// Transitions made by another backend instance only reach us through the database.
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			l.Debug("Client disconnected from planning events")
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			send(event)
+		case <-ticker.C:
+			if current, err := h.TripPlanningService.GetPlanningEvent(tripID); err == nil {
+				send(*current)
+			}
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
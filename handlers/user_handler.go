@@ -2,11 +2,14 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"travel-planning/models"
 	"travel-planning/services"
+
+	"github.com/gorilla/mux"
 )
 
 type UserHandlers struct {
@@ -134,3 +137,171 @@ func (h *UserHandlers) GetPreferencesHandler(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(prefs)
 }
+
+// GetBudgetProfilesHandler godoc
+// @Summary List budget allocation profiles
+// @Description Built-in profiles followed by the ones created by the authenticated user
+// @Security BearerAuth
+// @Tags Users
+// @Produce json
+// @Success 200 {array} models.BudgetProfile
+// @Router /api/users/budget-profiles [get]
+func (h *UserHandlers) GetBudgetProfilesHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := strconv.Atoi(r.Header.Get("X-User-ID"))
+	l := slog.With("user_id", userID, "path", r.URL.Path)
+
+	profiles, err := h.UserService.GetBudgetProfiles(userID)
+	if err != nil {
+		l.Error("Failed to fetch budget profiles", "error", err)
+		http.Error(w, "Error fetching budget profiles", http.StatusInternalServerError)
+		return
+	}
+	if profiles == nil {
+		profiles = []models.BudgetProfile{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profiles)
+}
+
+// CreateBudgetProfileHandler godoc
+// @Summary Create a budget allocation profile
+// @Description Logistics, activities and extra shares are percentages and must add up to 100
+// @Security BearerAuth
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param profile body models.BudgetProfile true "Profile"
+// @Success 201 {object} map[string]interface{} "profile_id"
+// @Failure 400 {string} string "Invalid profile"
+// @Router /api/users/budget-profiles [post]
+func (h *UserHandlers) CreateBudgetProfileHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := strconv.Atoi(r.Header.Get("X-User-ID"))
+	l := slog.With("user_id", userID, "path", r.URL.Path)
+
+	var req models.BudgetProfile
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+
+	profileID, err := h.UserService.CreateBudgetProfile(userID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidBudgetProfile) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		l.Error("Failed to create budget profile", "error", err)
+		http.Error(w, "Failed to create budget profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"profile_id": profileID,
+	})
+}
+
+// UpdateBudgetProfileHandler godoc
+// @Summary Update one of your budget allocation profiles
+// @Security BearerAuth
+// @Tags Users
+// @Accept json
+// @Param id path int true "Profile ID"
+// @Param profile body models.BudgetProfile true "Profile"
+// @Success 200 {string} string "Profile updated"
+// @Failure 400 {string} string "Invalid profile"
+// @Failure 404 {string} string "Profile not found"
+// @Router /api/users/budget-profiles/{id} [put]
+func (h *UserHandlers) UpdateBudgetProfileHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := strconv.Atoi(r.Header.Get("X-User-ID"))
+	profileID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || profileID <= 0 {
+		http.Error(w, "Invalid Profile ID", http.StatusBadRequest)
+		return
+	}
+	l := slog.With("user_id", userID, "profile_id", profileID)
+
+	var req models.BudgetProfile
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body format", http.StatusBadRequest)
+		return
+	}
+	req.ProfileID = profileID
+
+	err = h.UserService.UpdateBudgetProfile(userID, req)
+	switch {
+	case errors.Is(err, services.ErrInvalidBudgetProfile):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, services.ErrBudgetProfileNotFound):
+		http.Error(w, "Profile not found", http.StatusNotFound)
+	case err != nil:
+		l.Error("Failed to update budget profile", "error", err)
+		http.Error(w, "Failed to update budget profile", http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Profile updated"))
+	}
+}
+
+// DeleteBudgetProfileHandler godoc
+// @Summary Delete one of your budget allocation profiles
+// @Security BearerAuth
+// @Tags Users
+// @Param id path int true "Profile ID"
+// @Success 204 "No Content"
+// @Failure 404 {string} string "Profile not found"
+// @Router /api/users/budget-profiles/{id} [delete]
+func (h *UserHandlers) DeleteBudgetProfileHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := strconv.Atoi(r.Header.Get("X-User-ID"))
+	profileID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || profileID <= 0 {
+		http.Error(w, "Invalid Profile ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.UserService.DeleteBudgetProfile(userID, profileID)
+	if errors.Is(err, services.ErrBudgetProfileNotFound) {
+		http.Error(w, "Profile not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to delete budget profile", "user_id", userID, "profile_id", profileID, "error", err)
+		http.Error(w, "Failed to delete budget profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SelectBudgetProfileHandler godoc
+// @Summary Choose the budget allocation profile used for your trips
+// @Security BearerAuth
+// @Tags Users
+// @Param id path int true "Profile ID"
+// @Success 200 {string} string "Profile selected"
+// @Failure 404 {string} string "Profile not found"
+// @Router /api/users/budget-profiles/{id}/select [post]
+func (h *UserHandlers) SelectBudgetProfileHandler(w http.ResponseWriter, r *http.Request) {
+	userID, _ := strconv.Atoi(r.Header.Get("X-User-ID"))
+	profileID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || profileID <= 0 {
+		http.Error(w, "Invalid Profile ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.UserService.SelectBudgetProfile(userID, profileID)
+	if errors.Is(err, services.ErrBudgetProfileNotFound) {
+		http.Error(w, "Profile not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		slog.Warn("Failed to select budget profile", "user_id", userID, "profile_id", profileID, "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Profile selected"))
+}
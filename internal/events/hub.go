@@ -0,0 +1,59 @@
+package events
+
+import (
+	"sync"
+	"travel-planning/models"
+)
+
+// Hub fans planning events out to the clients watching a trip in this process.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan models.PlanningEvent]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[int]map[chan models.PlanningEvent]struct{}),
+	}
+}
+
+// Subscribe returns a channel of events for the trip and a function that must be
+// called to stop receiving them.
+func (h *Hub) Subscribe(tripID int) (<-chan models.PlanningEvent, func()) {
+	ch := make(chan models.PlanningEvent, 8)
+
+	h.mu.Lock()
+	if h.subscribers[tripID] == nil {
+		h.subscribers[tripID] = make(map[chan models.PlanningEvent]struct{})
+	}
+	h.subscribers[tripID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if subs, ok := h.subscribers[tripID]; ok {
+			if _, ok := subs[ch]; ok {
+				delete(subs, ch)
+				close(ch)
+			}
+			if len(subs) == 0 {
+				delete(h.subscribers, tripID)
+			}
+		}
+	}
+}
+
+// Publish never blocks: a subscriber whose buffer is full misses the event
+// and picks up the state on its next refresh.
+func (h *Hub) Publish(event models.PlanningEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[event.TripID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -12,7 +12,7 @@ import (
 type TripProcessor interface {
 	GetTripByID(id int) (*models.Trip, error)
 	GenerateOptions(tripID int) ([]models.TripOption, error)
-	FinalizeTripPlan(tripID int, tier string, hotelID int, outboundID int, inboundID int) error
+	UpdatePlanningStatus(tripID int, status string, reason string) error
 }
 
 type Consumer struct {
@@ -50,13 +50,25 @@ func (c *Consumer) Start(ctx context.Context) {
 		tripID := int(event["trip_id"].(float64))
 		slog.Info("Consumer picked up trip request", "trip_id", tripID)
 
-		_, err = c.service.GenerateOptions(tripID)
+		// Skip trips whose options are already being generated, like the HTTP endpoint does.
+		if err := c.service.UpdatePlanningStatus(tripID, models.PlanningGenerating, ""); err != nil {
+			slog.Warn("Skipping trip that cannot start generating", "trip_id", tripID, "error", err)
+			continue
+		}
+
+		options, err := c.service.GenerateOptions(tripID)
 		if err != nil {
 			slog.Error("Failed to generate options", "trip_id", tripID, "error", err)
+			if err := c.service.UpdatePlanningStatus(tripID, models.PlanningFailed, err.Error()); err != nil {
+				slog.Warn("Could not mark trip as failed", "trip_id", tripID, "error", err)
+			}
 			continue
 		}
 
-		slog.Info("Trip options are ready for user selection", "trip_id", tripID)
+		if err := c.service.UpdatePlanningStatus(tripID, models.PlanningOptionsReady, ""); err != nil {
+			slog.Warn("Could not mark trip options as ready", "trip_id", tripID, "error", err)
+		}
+		slog.Info("Trip options are ready for user selection", "trip_id", tripID, "count", len(options))
 
 	}
 }
@@ -0,0 +1,161 @@
+// Package pdf writes simple text documents as PDF 1.4 without external dependencies.
+// Coordinates are in points from the top-left corner of the page.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+)
+
+const (
+	PageWidth  = 595.28 // A4
+	PageHeight = 841.89
+)
+
+type Document struct {
+	title string
+	pages []*Page
+}
+
+type Page struct {
+	content bytes.Buffer
+}
+
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+func (d *Document) AddPage() *Page {
+	p := &Page{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Text draws a single line with its baseline at y.
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n",
+		font.resourceName(), size, x, PageHeight-y, escape(encode(s)))
+}
+
+// SetGray sets the fill and stroke colour, 0 is black and 1 white.
+func (p *Page) SetGray(gray float64) {
+	fmt.Fprintf(&p.content, "%.3f g %.3f G\n", gray, gray)
+}
+
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// FillRect fills a rectangle whose top-left corner is at x, y.
+func (p *Page) FillRect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f re f\n", x, PageHeight-y-h, w, h)
+}
+
+// TextWidth is the rendered width of s in points.
+func TextWidth(font Font, size float64, s string) float64 {
+	total := 0
+	for _, c := range encode(s) {
+		total += font.width(c)
+	}
+	return float64(total) * size / 1000
+}
+
+// Wrap splits s into lines no wider than maxWidth, breaking on spaces. Words longer than a
+// line are broken by characters. Newlines in s start a new line.
+func Wrap(font Font, size, maxWidth float64, s string) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		current := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if current != "" {
+				candidate = current + " " + word
+			}
+			if TextWidth(font, size, candidate) <= maxWidth {
+				current = candidate
+				continue
+			}
+			if current != "" {
+				lines = append(lines, current)
+			}
+			for TextWidth(font, size, word) > maxWidth {
+				cut := len([]rune(word)) - 1
+				for cut > 1 && TextWidth(font, size, string([]rune(word)[:cut])) > maxWidth {
+					cut--
+				}
+				lines = append(lines, string([]rune(word)[:cut]))
+				word = string([]rune(word)[cut:])
+			}
+			current = word
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+func escape(b []byte) string {
+	var out strings.Builder
+	for _, c := range b {
+		if c == '(' || c == ')' || c == '\\' {
+			out.WriteByte('\\')
+		}
+		out.WriteByte(c)
+	}
+	return out.String()
+}
+
+// Bytes serializes the document. Page contents are Flate compressed.
+func (d *Document) Bytes() ([]byte, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed; each page then takes a page object and a content stream.
+	const firstPageObject = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", Helvetica.baseName()))
+	object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", HelveticaBold.baseName()))
+	object(fmt.Sprintf("<< /Title (%s) /Producer (travel-planning) >>", escape(encode(d.title))))
+
+	for i, p := range d.pages {
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(p.content.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, firstPageObject+2*i+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream",
+			compressed.Len(), compressed.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,77 @@
+package pdf
+
+// Font is one of the standard 14 PDF fonts, which every viewer ships, so nothing has to be embedded.
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+func (f Font) baseName() string {
+	if f == HelveticaBold {
+		return "Helvetica-Bold"
+	}
+	return "Helvetica"
+}
+
+func (f Font) resourceName() string {
+	if f == HelveticaBold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// Glyph widths in 1/1000 em for WinAnsi codes 32..126, from the Adobe core font metrics.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+func (f Font) width(c byte) int {
+	if c < 32 || c > 126 {
+		// Accented letters and punctuation outside ASCII are close to an average glyph.
+		return 556
+	}
+	if f == HelveticaBold {
+		return helveticaBoldWidths[c-32]
+	}
+	return helveticaWidths[c-32]
+}
+
+// winAnsi maps the characters WinAnsiEncoding has outside Latin-1.
+var winAnsi = map[rune]byte{
+	'€': 128, '‚': 130, '„': 132, '…': 133, '‘': 145, '’': 146, '“': 147, '”': 148,
+	'•': 149, '–': 150, '—': 151, '™': 153,
+}
+
+// encode converts UTF-8 text to WinAnsi bytes; characters the standard fonts cannot show become '?'.
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 32 && r <= 126, r >= 160 && r <= 255:
+			out = append(out, byte(r))
+		case winAnsi[r] != 0:
+			out = append(out, winAnsi[r])
+		case r == '\t':
+			out = append(out, ' ')
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"travel-planning/database"
 	"travel-planning/handlers"
 	"travel-planning/internal/cache"
+	"travel-planning/internal/events"
 	"travel-planning/internal/kafka"
 	jobservice "travel-planning/jobService"
 	"travel-planning/server"
@@ -46,6 +48,14 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(os.Args[2:]); err != nil {
+			slog.Error("FATAL: migration command failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	slog.Info("Appliaction starting up")
 
 	db, err := database.NewDB()
@@ -68,10 +78,15 @@ func main() {
 	flightRepo := repository.NewFlightRepository(sqlConn)
 	userRepo := repository.NewUserRepository(sqlConn)
 	userPreferencesRepo := repository.NewUserPreferencesRepository(sqlConn)
+	budgetProfileRepo := repository.NewBudgetProfileRepository(sqlConn)
 	tripRepo := repository.NewTripRepository(sqlConn)
+	tripOptionRepo := repository.NewTripOptionRepository(sqlConn)
+	tripLegRepo := repository.NewTripLegRepository(sqlConn)
+	tripMemberRepo := repository.NewTripMemberRepository(sqlConn)
 	itineraryRepo := repository.NewTripItineraryRepository(sqlConn)
 	itineraryActivitiesRepo := repository.NewItineraryActivitiesRepository(sqlConn)
 	reviewRepo := repository.NewReviewRepository(sqlConn)
+	searchRepo := repository.NewSearchRepository(sqlConn)
 
 	amadeusService := services.NewAmadeusService()
 	countryAPIService := services.NewCountryAPIService(cacheService)
@@ -86,25 +101,32 @@ func main() {
 		slog.Warn("JWT_SECRET not found in environment, using default development secret")
 		jwtSecret = "default-development-secret-must-be-changed"
 	}
-	jwtService := services.NewJWTService(jwtSecret)
+	jwtService := services.NewJWTService(jwtSecret, userRepo)
 
 	authService := services.NewAuthService(userRepo, jwtService)
-	userService := services.NewUserService(userRepo, userPreferencesRepo)
-	resourceService := services.NewResourceService(hotelRepo, cityRepo, attractionRepo, countryRepo, restaurantRepo, flightRepo)
-	reviewService := services.NewReviewService(reviewRepo)
+	userService := services.NewUserService(userRepo, userPreferencesRepo, budgetProfileRepo)
+	// Comma-separated emails of registered users who get the admin role on startup.
+	userService.PromoteAdmins(strings.Split(os.Getenv("ADMIN_EMAILS"), ","))
+	resourceService := services.NewResourceService(hotelRepo, cityRepo, attractionRepo, countryRepo, restaurantRepo, flightRepo, searchRepo)
+	reviewService := services.NewReviewService(reviewRepo, userRepo)
 
 	kafkaProducer := kafka.NewProducer("kafka:9092")
 	defer kafkaProducer.Close()
 
 	tripPlanningService := services.NewTripPlanningService(
 		tripRepo,
+		tripOptionRepo,
+		tripLegRepo,
+		tripMemberRepo,
 		itineraryRepo,
 		itineraryActivitiesRepo,
 		flightRepo,
 		hotelRepo,
 		attractionRepo,
 		restaurantRepo,
-		userPreferencesRepo, kafkaProducer)
+		cityRepo,
+		userPreferencesRepo,
+		budgetProfileRepo, kafkaProducer, events.NewHub())
 
 	kafkaConsumer := kafka.NewConsumer([]string{"kafka:9092"}, "trip-requests", "trip-service-group", tripPlanningService)
 	defer kafkaConsumer.Close()
@@ -212,6 +234,14 @@ func main() {
 	reviewHandlers := handlers.NewReviewHandlers(reviewService)
 
 	tripHandlers := handlers.NewTripHandlers(tripPlanningService)
+	adminHandlers := handlers.NewAdminHandlers(userService, map[string]handlers.SeedJob{
+		"countries":   countryJob,
+		"cities":      jobservice.NewCityJob(seeder),
+		"attractions": jobservice.NewAttractionJob(seeder),
+		"hotels":      jobservice.NewHotelJob(seeder),
+		"restaurants": jobservice.NewRestaurantJob(seeder),
+		"flights":     jobservice.NewFlightJob(seeder),
+	})
 
 	appServer := server.NewAppServer(
 		authHandlers,
@@ -219,6 +249,7 @@ func main() {
 		reviewHandlers,
 		userHandlers,
 		tripHandlers,
+		adminHandlers,
 		jwtService,
 	)
 	appServer.Start(":8080")
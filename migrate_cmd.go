@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"travel-planning/database"
+)
+
+// runMigrateCommand handles `migrate up`, `migrate down [steps]` and `migrate version`.
+func runMigrateCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up | down [steps] | version")
+	}
+
+	db, err := database.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db.GetConn())
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("invalid steps value: %s", args[1])
+			}
+		}
+		return migrator.Down(ctx, steps)
+	case "version":
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		slog.Info("Current schema version", "version", version)
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command: %s", args[0])
+	}
+}
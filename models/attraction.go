@@ -2,16 +2,22 @@ package models
 
 import "time"
 
+// Attraction, Hotel and Restaurant carry two ratings. Rating is the catalog rating that every
+// listing, search, nearby and itinerary view shows. BlendedRating is Rating pulled towards the
+// average of the approved user reviews (migration 0013); only the itinerary planning queries
+// fill it, together with ReviewCount, and rank and score places by it.
 type Attraction struct {
-	AttractionID int       `json:"attraction_id" db:"attraction_id"`
-	CityID       int       `json:"city_id" db:"city_id"`
-	Name         string    `json:"name" db:"name"`
-	Category     string    `json:"category" db:"category"`
-	Latitude     float64   `json:"latitude" db:"latitude"`
-	Longitude    float64   `json:"longitude" db:"longitude"`
-	Rating       float64   `json:"rating" db:"rating"`
-	EntryFee     float64   `json:"entry_fee" db:"entry_fee"`
-	Website      string    `json:"website" db:"website"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	AttractionID  int       `json:"attraction_id" db:"attraction_id"`
+	CityID        int       `json:"city_id" db:"city_id"`
+	Name          string    `json:"name" db:"name"`
+	Category      string    `json:"category" db:"category"`
+	Latitude      float64   `json:"latitude" db:"latitude"`
+	Longitude     float64   `json:"longitude" db:"longitude"`
+	Rating        float64   `json:"rating" db:"rating"`
+	BlendedRating float64   `json:"blended_rating,omitempty" db:"blended_rating"`
+	ReviewCount   int       `json:"review_count,omitempty" db:"review_count"`
+	EntryFee      float64   `json:"entry_fee" db:"entry_fee"`
+	Website       string    `json:"website" db:"website"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+const DefaultBudgetProfileName = "balanced"
+
+// BudgetProfile splits a trip budget into logistics, activities and extra money.
+// Shares are percentages; FlightShare is the part of logistics spent on flights.
+type BudgetProfile struct {
+	ProfileID       int       `json:"profile_id" db:"profile_id"`
+	UserID          *int      `json:"user_id,omitempty" db:"user_id"`
+	Name            string    `json:"name" db:"name"`
+	LogisticsShare  float64   `json:"logistics_share" db:"logistics_share"`
+	ActivitiesShare float64   `json:"activities_share" db:"activities_share"`
+	ExtraShare      float64   `json:"extra_share" db:"extra_share"`
+	FlightShare     float64   `json:"flight_share" db:"flight_share"`
+	BuiltIn         bool      `json:"built_in"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
@@ -10,6 +10,7 @@ type City struct {
 	Longitude   float64   `json:"longitude" db:"longitude"`
 	IataCode    string    `json:"iata_code" db:"iata_code"`
 	Description string    `json:"description" db:"description"`
+	Timezone    string    `json:"timezone,omitempty" db:"timezone"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -0,0 +1,32 @@
+package models
+
+// FeatureCollection is a GeoJSON (RFC 7946) collection. Coordinates are [longitude, latitude].
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         int64                  `json:"id,omitempty"`
+	Geometry   Geometry               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+func NewFeatureCollection() FeatureCollection {
+	return FeatureCollection{Type: "FeatureCollection", Features: []Feature{}}
+}
+
+func NewPointFeature(id int64, lat, lon float64, properties map[string]interface{}) Feature {
+	return Feature{
+		Type:       "Feature",
+		ID:         id,
+		Geometry:   Geometry{Type: "Point", Coordinates: []float64{lon, lat}},
+		Properties: properties,
+	}
+}
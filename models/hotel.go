@@ -7,8 +7,12 @@ type Hotel struct {
 	CityID        int       `json:"city_id" db:"city_id"`
 	Name          string    `json:"name" db:"name"`
 	Address       string    `json:"address" db:"address"`
+	Latitude      float64   `json:"latitude" db:"latitude"`
+	Longitude     float64   `json:"longitude" db:"longitude"`
 	Stars         int       `json:"stars" db:"stars"`
 	Rating        float64   `json:"rating" db:"rating"`
+	BlendedRating float64   `json:"blended_rating,omitempty" db:"blended_rating"`
+	ReviewCount   int       `json:"review_count,omitempty" db:"review_count"`
 	PricePerNight float64   `json:"price_per_night" db:"price_per_night"`
 	Website       string    `json:"website" db:"website"`
 	Description   string    `json:"description" db:"description"`
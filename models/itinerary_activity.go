@@ -18,9 +18,11 @@ type ItineraryActivity struct {
 	EndTime      time.Time     `json:"end_time" db:"end_time"`
 	Notes        string        `json:"notes" db:"notes"`
 
-	EntityName   string    `json:"entity_name"`
-	EntityDetail string    `json:"entity_detail"`
-	EntityExtra  string    `json:"entity_extra"`
-	EntityRating float64   `json:"entity_rating"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	EntityName      string    `json:"entity_name"`
+	EntityDetail    string    `json:"entity_detail"`
+	EntityExtra     string    `json:"entity_extra"`
+	EntityRating    float64   `json:"entity_rating"`
+	EntityLatitude  float64   `json:"entity_latitude"`
+	EntityLongitude float64   `json:"entity_longitude"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 }
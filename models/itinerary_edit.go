@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type ActivityCreateRequest struct {
+	ActivityType string    `json:"activity_type"`
+	EntityID     int       `json:"entity_id"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Notes        string    `json:"notes"`
+}
+
+// ActivityMoveRequest moves an activity to another day of the same trip and/or another time.
+// Omitted fields keep the current day and clock time.
+type ActivityMoveRequest struct {
+	ItineraryID int64      `json:"itinerary_id"`
+	StartTime   *time.Time `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+}
+
+type ActivityReplaceRequest struct {
+	EntityID int `json:"entity_id"`
+}
+
+type ActivityAlternative struct {
+	ActivityType string  `json:"activity_type"`
+	EntityID     int     `json:"entity_id"`
+	Name         string  `json:"name"`
+	Detail       string  `json:"detail"`
+	Rating       float64 `json:"rating"`
+	DistanceKm   float64 `json:"distance_km"`
+}
@@ -0,0 +1,23 @@
+package models
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// ListParams are the paging options shared by the list endpoints. Sort is a sort key,
+// prefixed with "-" for descending order. Cursor is the opaque next_cursor of the previous page.
+type ListParams struct {
+	Limit  int
+	Cursor string
+	Sort   string
+}
+
+// Page is the envelope every list endpoint responds with.
+type Page[T any] struct {
+	Data       []T    `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Limit      int    `json:"limit"`
+	Sort       string `json:"sort"`
+}
@@ -0,0 +1,39 @@
+package models
+
+// BoundingBox is an area in GeoJSON bbox order. MinLon > MaxLon means the box crosses the antimeridian.
+type BoundingBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+func (b BoundingBox) Contains(lat, lon float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.MinLon <= b.MaxLon {
+		return lon >= b.MinLon && lon <= b.MaxLon
+	}
+	return lon >= b.MinLon || lon <= b.MaxLon
+}
+
+// ResourceFilter narrows the catalog listings. Zero values don't filter, and every listing
+// ignores the fields that don't apply to it (e.g. cuisine for hotels).
+type ResourceFilter struct {
+	CityID     int
+	CountryID  int
+	BBox       *BoundingBox
+	Category   string
+	Cuisine    string
+	PriceRange string
+	MinRating  float64
+	MaxPrice   float64
+	Stars      int
+
+	// Flights only; CityID matches either end of a flight.
+	FromCityID int
+	ToCityID   int
+
+	ListParams
+}
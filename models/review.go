@@ -2,17 +2,30 @@ package models
 
 import "time"
 
+// Review moderation states. Only approved reviews are public and count towards ratings.
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
 type Review struct {
-	ReviewID   int       `json:"review_id" db:"review_id"`
-	EntityType string    `json:"entity_type" db:"entity_type"`
-	EntityID   int       `json:"entity_id" db:"entity_id"`
-	UserID     int       `json:"user_id" db:"user_id"`
-	Rating     int       `json:"rating" db:"rating"`
-	Comment    string    `json:"comment" db:"comment"`
-	ReviewDate time.Time `json:"review_date" db:"review_date"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-
-	EntityName string `json:"entity_name"`
+	ReviewID       int        `json:"review_id" db:"review_id"`
+	EntityType     string     `json:"entity_type" db:"entity_type"`
+	EntityID       int        `json:"entity_id" db:"entity_id"`
+	UserID         int        `json:"user_id" db:"user_id"`
+	Rating         int        `json:"rating" db:"rating"`
+	Comment        string     `json:"comment" db:"comment"`
+	Verified       bool       `json:"verified" db:"verified"`
+	Status         string     `json:"status" db:"status"`
+	Flags          []string   `json:"flags,omitempty" db:"flags"`
+	ModerationNote string     `json:"moderation_note,omitempty" db:"moderation_note"`
+	ReviewDate     time.Time  `json:"review_date" db:"review_date"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+
+	EntityName   string `json:"entity_name"`
+	ReviewerName string `json:"reviewer_name,omitempty"`
 }
 
 type CreateReviewRequest struct {
@@ -21,3 +34,35 @@ type CreateReviewRequest struct {
 	Rating     int    `json:"rating"`
 	Comment    string `json:"comment"`
 }
+
+type UpdateReviewRequest struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+// ReviewEdit is a previous version of an edited review.
+type ReviewEdit struct {
+	EditID   int       `json:"edit_id" db:"edit_id"`
+	ReviewID int       `json:"review_id" db:"review_id"`
+	Rating   int       `json:"rating" db:"rating"`
+	Comment  string    `json:"comment" db:"comment"`
+	EditedAt time.Time `json:"edited_at" db:"edited_at"`
+}
+
+type ModerationRequest struct {
+	Note string `json:"note"`
+}
+
+// ReviewSummary aggregates every review of one place. Histogram counts reviews per
+// star rating, 1 through 5.
+type ReviewSummary struct {
+	Count     int         `json:"count"`
+	Average   float64     `json:"average"`
+	Histogram map[int]int `json:"histogram"`
+}
+
+// PlaceReviewPage is one page of a place's reviews with the summary of all of them.
+type PlaceReviewPage struct {
+	Page[Review]
+	Summary ReviewSummary `json:"summary"`
+}
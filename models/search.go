@@ -0,0 +1,70 @@
+package models
+
+// Place types covered by /api/search.
+const (
+	PlaceCity       = "city"
+	PlaceAttraction = "attraction"
+	PlaceHotel      = "hotel"
+	PlaceRestaurant = "restaurant"
+)
+
+// PlaceTypes lists every searchable entity type.
+var PlaceTypes = []string{PlaceCity, PlaceAttraction, PlaceHotel, PlaceRestaurant}
+
+// SearchQuery is one ranked lookup over the place catalog.
+// CityID and RadiusKm (around Latitude/Longitude) narrow the candidates when set.
+type SearchQuery struct {
+	Text      string
+	Types     []string
+	CityID    int
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	Limit     int
+}
+
+type SearchResult struct {
+	EntityType  string   `json:"entity_type"`
+	EntityID    int      `json:"entity_id"`
+	Name        string   `json:"name"`
+	Detail      string   `json:"detail,omitempty"`
+	CityID      int      `json:"city_id"`
+	CityName    string   `json:"city_name"`
+	CountryName string   `json:"country_name"`
+	Latitude    *float64 `json:"latitude,omitempty"`
+	Longitude   *float64 `json:"longitude,omitempty"`
+	Rating      float64  `json:"rating"`
+	DistanceKm  *float64 `json:"distance_km,omitempty"`
+	Score       float64  `json:"score"`
+}
+
+// SearchResponse echoes how the query was read: "sushi near Shibuya" searches
+// for "sushi" around the place Shibuya resolved to.
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Terms   string         `json:"terms"`
+	Near    *SearchResult  `json:"near,omitempty"`
+	Results []SearchResult `json:"results"`
+}
+
+// NearbyQuery selects places within RadiusKm of a point.
+type NearbyQuery struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	Types     []string
+	Limit     int
+}
+
+type NearbyPlace struct {
+	EntityType string  `json:"entity_type"`
+	EntityID   int     `json:"entity_id"`
+	Name       string  `json:"name"`
+	Detail     string  `json:"detail,omitempty"`
+	CityID     int     `json:"city_id"`
+	CityName   string  `json:"city_name"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	Rating     float64 `json:"rating"`
+	DistanceKm float64 `json:"distance_km"`
+}
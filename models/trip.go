@@ -11,7 +11,15 @@ type Trip struct {
 	EndDate           time.Time `json:"end_date" db:"end_date"`
 	Duration          int       `json:"duration" db:"duration"`
 	TotalPrice        float64   `json:"total_price" db:"total_price"`
+	Adults            int       `json:"adults" db:"adults"`
+	Children          int       `json:"children" db:"children"`
 	Status            string    `json:"status" db:"status"`
+	Tier              string    `json:"tier,omitempty" db:"tier"`
+	ActivitiesBudget  float64   `json:"activities_budget,omitempty" db:"activities_budget"`
+	PlanningStatus    string    `json:"planning_status" db:"planning_status"`
+	PlanningError     string    `json:"planning_error,omitempty" db:"planning_error"`
+	PlanningUpdatedAt time.Time `json:"planning_updated_at" db:"planning_updated_at"`
+	AccessRole        string    `json:"access_role,omitempty"`
 	CreatedAt         time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -23,10 +31,17 @@ type TripPlanRequest struct {
 	ToCityID     int     `json:"destination_city_id"`
 	Duration     int     `json:"duration"`
 	BudgetAmount float64 `json:"total_price"`
+	Adults       int     `json:"adults"`
+	Children     int     `json:"children"`
+
+	Legs []TripLegRequest `json:"legs,omitempty"`
 }
 
 type TripOption struct {
+	OptionID         int     `json:"option_id"`
+	TripID           int     `json:"trip_id"`
 	Tier             string  `json:"tier"`
+	BudgetProfile    string  `json:"budget_profile"`
 	OutBoundFlight   *Flight `json:"outbound_flight"`
 	InBoundFlight    *Flight `json:"inbound_flight"`
 	Hotel            *Hotel  `json:"hotel"`
@@ -34,4 +49,27 @@ type TripOption struct {
 	ActivitiesBudget float64 `json:"activites_budget"`
 	MoreMoney        float64 `json:"more_money"`
 	TotalPriceOfTrip float64 `json:"total_price_of_money"`
+
+	Adults         int     `json:"adults"`
+	Children       int     `json:"children"`
+	Rooms          int     `json:"rooms"`
+	FlightsCost    float64 `json:"flights_cost"`
+	HotelCost      float64 `json:"hotel_cost"`
+	PerPersonPrice float64 `json:"per_person_price"`
+
+	Legs []TripOptionLeg `json:"legs"`
+}
+
+const (
+	PlanningQueued       = "queued"
+	PlanningGenerating   = "generating"
+	PlanningOptionsReady = "options_ready"
+	PlanningFailed       = "failed"
+)
+
+type PlanningEvent struct {
+	TripID    int       `json:"trip_id"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
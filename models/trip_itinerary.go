@@ -9,5 +9,6 @@ type TripItinerary struct {
 	Notes       string    `json:"notes" db:"notes"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	Date        time.Time `json:"date" db:"date"`
+	RouteKm     float64   `json:"route_distance_km" db:"route_distance_km"`
 	TripStatus  string    `json:"trip_status"`
 }
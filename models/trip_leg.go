@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type TripLeg struct {
+	LegID         int       `json:"leg_id" db:"leg_id"`
+	TripID        int       `json:"trip_id" db:"trip_id"`
+	LegOrder      int       `json:"leg_order" db:"leg_order"`
+	CityID        int       `json:"city_id" db:"city_id"`
+	ArrivalDate   time.Time `json:"arrival_date" db:"arrival_date"`
+	DepartureDate time.Time `json:"departure_date" db:"departure_date"`
+}
+
+func (l TripLeg) Nights() int {
+	return int(l.DepartureDate.Sub(l.ArrivalDate).Hours() / 24)
+}
+
+type TripLegRequest struct {
+	CityID        int    `json:"city_id"`
+	ArrivalDate   string `json:"arrival_date"`
+	DepartureDate string `json:"departure_date"`
+}
+
+type TripOptionLeg struct {
+	LegOrder      int     `json:"leg_order"`
+	CityID        int     `json:"city_id"`
+	Nights        int     `json:"nights"`
+	Hotel         *Hotel  `json:"hotel"`
+	ArrivalFlight *Flight `json:"arrival_flight"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Trip roles, from most to least privileged. The owner is the trip's creator and
+// is not stored in trip_members.
+const (
+	TripRoleOwner  = "owner"
+	TripRoleEditor = "editor"
+	TripRoleViewer = "viewer"
+)
+
+type TripMember struct {
+	TripID    int       `json:"trip_id" db:"trip_id"`
+	UserID    int       `json:"user_id" db:"user_id"`
+	Role      string    `json:"role" db:"role"`
+	InvitedBy int       `json:"invited_by,omitempty" db:"invited_by"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type TripInviteRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
@@ -2,12 +2,18 @@ package models
 
 import "time"
 
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin"
+)
+
 type User struct {
 	UserID       int       `json:"user_id" db:"user_id"`
 	FirstName    string    `json:"first_name" db:"first_name"`
 	LastName     string    `json:"last_name" db:"last_name"`
 	Email        string    `json:"email" db:"email"`
 	PasswordHash string    `json:"password_hash" db:"password_hash"`
+	Role         string    `json:"role" db:"role"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 }
 
@@ -16,6 +16,7 @@ type UserPreferences struct {
 	UserID              int       `json:"user_id" db:"user_id"`
 	HomeCityID          int       `json:"home_city_id" db:"home_city_id"`
 	PreferredCategories string    `json:"preferred_categories" db:"preferred_categories"`
+	BudgetProfileID     *int      `json:"budget_profile_id,omitempty" db:"budget_profile_id"`
 	CreatedAt           time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"time"
 	"travel-planning/models"
+
+	"github.com/lib/pq"
 )
 
 type AttractionRepository struct {
@@ -63,16 +65,78 @@ func (r *AttractionRepository) Upsert(attraction *models.Attraction) (int, error
 	return attractionID, nil
 }
 
-func (r *AttractionRepository) GetAllAttractions() ([]models.Attraction, error) {
+// Update overwrites the editable fields of an attraction. It reports false when the
+// attraction does not exist.
+func (r *AttractionRepository) Update(attraction *models.Attraction) (bool, error) {
+	query := `UPDATE attractions
+          SET name = $2, category = $3, latitude = $4, longitude = $5, rating = $6, entry_fee = $7,
+              website = $8, updated_at = NOW() AT TIME ZONE 'Asia/Yerevan'
+          WHERE attraction_id = $1
+          RETURNING city_id, updated_at`
+
+	err := r.db.QueryRow(query,
+		attraction.AttractionID,
+		attraction.Name,
+		attraction.Category,
+		attraction.Latitude,
+		attraction.Longitude,
+		attraction.Rating,
+		attraction.EntryFee,
+		attraction.Website,
+	).Scan(&attraction.CityID, &attraction.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, ErrCatalogConflict
+		}
+		slog.Error("Failed to update attraction", "attraction_id", attraction.AttractionID, "error", err)
+		return false, fmt.Errorf("failed to update attraction %d: %w", attraction.AttractionID, err)
+	}
+	return true, nil
+}
+
+var attractionList = listSpec[models.Attraction]{
+	idExpr: "a.attraction_id",
+	id:     func(a models.Attraction) int { return a.AttractionID },
+	sorts: map[string]sortKey[models.Attraction]{
+		"id":        {"a.attraction_id", func(a models.Attraction) interface{} { return a.AttractionID }},
+		"name":      {"a.name", func(a models.Attraction) interface{} { return a.Name }},
+		"rating":    {"COALESCE(a.rating, 0)", func(a models.Attraction) interface{} { return a.Rating }},
+		"entry_fee": {"COALESCE(a.entry_fee, 0)", func(a models.Attraction) interface{} { return a.EntryFee }},
+	},
+	defaultSort: "id",
+}
+
+// GetAllAttractions returns one page of attractions matching the filter.
+// Sort keys: id, name, rating, entry_fee.
+func (r *AttractionRepository) GetAllAttractions(filter models.ResourceFilter) (models.Page[models.Attraction], error) {
+	var where whereBuilder
+	where.addLocationFilter(filter, "a")
+	if filter.Category != "" {
+		where.add("LOWER(a.category) = LOWER(?)", filter.Category)
+	}
+	if filter.MinRating > 0 {
+		where.add("a.rating >= ?", filter.MinRating)
+	}
+	if filter.MaxPrice > 0 {
+		where.add("COALESCE(a.entry_fee, 0) <= ?", filter.MaxPrice)
+	}
+	plan, err := attractionList.plan(&where, filter.ListParams)
+	if err != nil {
+		return models.Page[models.Attraction]{}, err
+	}
+
 	query := `SELECT 
                 attraction_id, city_id, name, category, latitude, longitude, 
                 rating, entry_fee, website, created_at, updated_at
-              FROM attractions;`
+              FROM attractions a` + where.clause() + plan.suffix()
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, where.args...)
 	if err != nil {
 		slog.Error("Failed to fetch all attractions", "error", err)
-		return nil, fmt.Errorf("failed to fetch all attractions: %w", err)
+		return models.Page[models.Attraction]{}, fmt.Errorf("failed to fetch all attractions: %w", err)
 	}
 	defer rows.Close()
 
@@ -97,33 +161,39 @@ func (r *AttractionRepository) GetAllAttractions() ([]models.Attraction, error)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %w", err)
+		return models.Page[models.Attraction]{}, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	return attractions, nil
+	return plan.page(attractions), nil
 }
 
-func (s *AttractionRepository) GetBestAttractionsByTier(cityID int, budgetLimit float64, tier string) ([]models.Attraction, error) {
-	slog.Info("Fetching best attractions", "city_id", cityID, "budget_limit", budgetLimit, "tier", tier)
+// GetBestAttractionsByTier returns candidates for the itinerary. Attractions in one of the
+// preferred categories are fetched first so the planner has enough of them to score.
+func (s *AttractionRepository) GetBestAttractionsByTier(cityID int, budgetLimit float64, tier string, preferredCategories []string) ([]models.Attraction, error) {
+	slog.Info("Fetching best attractions", "city_id", cityID, "budget_limit", budgetLimit, "tier", tier, "preferred", preferredCategories)
 
 	var orderBy string
 	switch tier {
 	case "Economy":
-		orderBy = "entry_fee ASC, rating DESC"
+		orderBy = "entry_fee ASC, blended_rating DESC"
 	case "Luxury":
-		orderBy = "rating DESC, entry_fee DESC"
+		orderBy = "blended_rating DESC, entry_fee DESC"
 	default:
-		orderBy = "rating DESC, entry_fee ASC"
+		orderBy = "blended_rating DESC, entry_fee ASC"
 	}
 
 	query := fmt.Sprintf(`
-		SELECT attraction_id, city_id, name, category, latitude, longitude, rating, entry_fee, website
+		SELECT attraction_id, city_id, name, category, latitude, longitude,
+		       COALESCE(rating, 0), blended_rating, review_count, entry_fee, website
 		FROM attractions 
 		WHERE city_id = $1 AND entry_fee <= $2
-		ORDER BY %s
-		LIMIT 10`, orderBy)
+		ORDER BY (LOWER(category) = ANY($3)) DESC, %s
+		LIMIT 30`, orderBy)
 
-	rows, err := s.db.Query(query, cityID, budgetLimit)
+	if preferredCategories == nil {
+		preferredCategories = []string{}
+	}
+	rows, err := s.db.Query(query, cityID, budgetLimit, pq.Array(preferredCategories))
 	if err != nil {
 		slog.Error("Database query failed in GetBestAttractionsByTier", "error", err, "city_id", cityID)
 		return nil, err
@@ -141,6 +211,8 @@ func (s *AttractionRepository) GetBestAttractionsByTier(cityID int, budgetLimit
 			&a.Latitude,
 			&a.Longitude,
 			&a.Rating,
+			&a.BlendedRating,
+			&a.ReviewCount,
 			&a.EntryFee,
 			&a.Website); err != nil {
 			slog.Warn("Skipping attraction row due to scan error", "error", err)
@@ -185,3 +257,60 @@ func (r *AttractionRepository) GetVisitedAttractions(userID int) ([]models.Attra
 	}
 	return attractions, nil
 }
+
+// GetByID returns nil when the attraction does not exist.
+func (r *AttractionRepository) GetByID(attractionID int) (*models.Attraction, error) {
+	query := `SELECT attraction_id, city_id, name, category, latitude, longitude,
+                COALESCE(rating, 0), COALESCE(entry_fee, 0), COALESCE(website, '')
+              FROM attractions WHERE attraction_id = $1`
+
+	var a models.Attraction
+	err := r.db.QueryRow(query, attractionID).Scan(
+		&a.AttractionID, &a.CityID, &a.Name, &a.Category, &a.Latitude, &a.Longitude,
+		&a.Rating, &a.EntryFee, &a.Website,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("Database error in attraction GetByID", "attraction_id", attractionID, "error", err)
+		return nil, fmt.Errorf("failed to fetch attraction %d: %w", attractionID, err)
+	}
+	return &a, nil
+}
+
+// GetNearby returns attractions of the city closest to the point, skipping excludeIDs.
+// Distance is ranked on an equirectangular approximation, which is exact enough inside a city.
+func (r *AttractionRepository) GetNearby(cityID int, lat, lon float64, excludeIDs []int, limit int) ([]models.Attraction, error) {
+	query := `
+		SELECT attraction_id, city_id, name, category, latitude, longitude,
+		       COALESCE(rating, 0), COALESCE(entry_fee, 0), COALESCE(website, '')
+		FROM attractions
+		WHERE city_id = $1 AND NOT (attraction_id = ANY($4))
+		ORDER BY power(latitude - $2, 2) + power((longitude - $3) * cos(radians($2)), 2) ASC
+		LIMIT $5`
+
+	if excludeIDs == nil {
+		excludeIDs = []int{}
+	}
+	rows, err := r.db.Query(query, cityID, lat, lon, pq.Array(excludeIDs), limit)
+	if err != nil {
+		slog.Error("Database error in attraction GetNearby", "city_id", cityID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []models.Attraction
+	for rows.Next() {
+		var a models.Attraction
+		if err := rows.Scan(
+			&a.AttractionID, &a.CityID, &a.Name, &a.Category, &a.Latitude, &a.Longitude,
+			&a.Rating, &a.EntryFee, &a.Website,
+		); err != nil {
+			slog.Warn("Skipping attraction row due to scan error", "error", err)
+			continue
+		}
+		results = append(results, a)
+	}
+	return results, rows.Err()
+}
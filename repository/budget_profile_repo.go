@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+	"travel-planning/models"
+)
+
+type BudgetProfileRepository struct {
+	db *sql.DB
+}
+
+func NewBudgetProfileRepository(db *sql.DB) *BudgetProfileRepository {
+	return &BudgetProfileRepository{db: db}
+}
+
+const budgetProfileSelect = `
+    SELECT profile_id, user_id, name, logistics_share, activities_share, extra_share, flight_share, created_at
+    FROM budget_profiles`
+
+func scanBudgetProfile(row rowScanner) (*models.BudgetProfile, error) {
+	var p models.BudgetProfile
+	var userID sql.NullInt64
+	if err := row.Scan(
+		&p.ProfileID,
+		&userID,
+		&p.Name,
+		&p.LogisticsShare,
+		&p.ActivitiesShare,
+		&p.ExtraShare,
+		&p.FlightShare,
+		&p.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if userID.Valid {
+		id := int(userID.Int64)
+		p.UserID = &id
+	}
+	p.BuiltIn = !userID.Valid
+	return &p, nil
+}
+
+// GetAvailableForUser returns the built-in profiles followed by the user's own ones.
+func (r *BudgetProfileRepository) GetAvailableForUser(userID int) ([]models.BudgetProfile, error) {
+	query := budgetProfileSelect + `
+    WHERE user_id IS NULL OR user_id = $1
+    ORDER BY user_id NULLS FIRST, name ASC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		slog.Error("Failed to fetch budget profiles", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to fetch budget profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []models.BudgetProfile
+	for rows.Next() {
+		p, err := scanBudgetProfile(rows)
+		if err != nil {
+			slog.Warn("Error scanning budget profile row", "error", err)
+			continue
+		}
+		profiles = append(profiles, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return profiles, nil
+}
+
+// GetAvailableByID returns nil when the profile does not exist or belongs to another user.
+func (r *BudgetProfileRepository) GetAvailableByID(profileID, userID int) (*models.BudgetProfile, error) {
+	query := budgetProfileSelect + `
+    WHERE profile_id = $1 AND (user_id IS NULL OR user_id = $2)`
+
+	p, err := scanBudgetProfile(r.db.QueryRow(query, profileID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("Database error fetching budget profile", "profile_id", profileID, "error", err)
+		return nil, fmt.Errorf("failed to fetch budget profile %d: %w", profileID, err)
+	}
+	return p, nil
+}
+
+// GetForUser returns the profile selected in the user's preferences, falling back to the
+// built-in default. It returns nil when neither exists.
+func (r *BudgetProfileRepository) GetForUser(userID int) (*models.BudgetProfile, error) {
+	query := budgetProfileSelect + `
+    WHERE profile_id = (SELECT budget_profile_id FROM user_preferences WHERE user_id = $1)
+       OR (user_id IS NULL AND name = $2)
+    ORDER BY (user_id IS NULL AND name = $2) ASC
+    LIMIT 1`
+
+	p, err := scanBudgetProfile(r.db.QueryRow(query, userID, models.DefaultBudgetProfileName))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("Database error resolving budget profile", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to resolve budget profile for user %d: %w", userID, err)
+	}
+	return p, nil
+}
+
+func (r *BudgetProfileRepository) Insert(p *models.BudgetProfile) (int, error) {
+	query := `INSERT INTO budget_profiles (user_id, name, logistics_share, activities_share, extra_share, flight_share, created_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING profile_id;`
+
+	var profileID int
+	err := r.db.QueryRow(
+		query,
+		p.UserID,
+		p.Name,
+		p.LogisticsShare,
+		p.ActivitiesShare,
+		p.ExtraShare,
+		p.FlightShare,
+		time.Now(),
+	).Scan(&profileID)
+
+	if err != nil {
+		slog.Error("Failed to insert budget profile", "name", p.Name, "error", err)
+		return 0, fmt.Errorf("failed to insert budget profile: %w", err)
+	}
+	return profileID, nil
+}
+
+// Update changes one of the user's own profiles. It reports false when no such profile exists.
+func (r *BudgetProfileRepository) Update(p *models.BudgetProfile, userID int) (bool, error) {
+	query := `UPDATE budget_profiles
+              SET name = $1, logistics_share = $2, activities_share = $3, extra_share = $4, flight_share = $5
+              WHERE profile_id = $6 AND user_id = $7`
+
+	res, err := r.db.Exec(query, p.Name, p.LogisticsShare, p.ActivitiesShare, p.ExtraShare, p.FlightShare, p.ProfileID, userID)
+	if err != nil {
+		slog.Error("Failed to update budget profile", "profile_id", p.ProfileID, "error", err)
+		return false, fmt.Errorf("failed to update budget profile: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows > 0, nil
+}
+
+// Delete removes one of the user's own profiles. It reports false when no such profile exists.
+func (r *BudgetProfileRepository) Delete(profileID, userID int) (bool, error) {
+	res, err := r.db.Exec(`DELETE FROM budget_profiles WHERE profile_id = $1 AND user_id = $2`, profileID, userID)
+	if err != nil {
+		slog.Error("Failed to delete budget profile", "profile_id", profileID, "error", err)
+		return false, fmt.Errorf("failed to delete budget profile: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows > 0, nil
+}
@@ -19,12 +19,13 @@ func NewCityRepository(db *sql.DB) *CityRepository {
 }
 
 func (r *CityRepository) Upsert(city *models.City) (int, error) {
-	query := `INSERT INTO cities  (country_id, name, latitude, longitude, description, created_at, updated_at) 
-			VALUES ($1, $2, $3, $4, $5, $6,$7) 
+	query := `INSERT INTO cities  (country_id, name, latitude, longitude, description, created_at, updated_at, timezone) 
+			VALUES ($1, $2, $3, $4, $5, $6,$7, NULLIF($8, '')) 
 			ON CONFLICT (latitude, longitude, country_id) DO UPDATE
 			SET 
 				name = EXCLUDED.name,
 				description = EXCLUDED.description,
+				timezone = COALESCE(EXCLUDED.timezone, cities.timezone),
 				updated_at = NOW() AT TIME ZONE 'Asia/Yerevan'
 			RETURNING city_id;`
 
@@ -50,6 +51,7 @@ func (r *CityRepository) Upsert(city *models.City) (int, error) {
 		city.Description,
 		city.CreatedAt,
 		city.UpdatedAt,
+		city.Timezone,
 	).Scan(&cityID)
 
 	if err != nil {
@@ -65,6 +67,35 @@ func (r *CityRepository) Upsert(city *models.City) (int, error) {
 	return cityID, nil
 }
 
+// GetCityByID returns nil when the city does not exist.
+func (r *CityRepository) GetCityByID(cityID int) (*models.City, error) {
+	query := `SELECT city_id, country_id, name, latitude, longitude, COALESCE(iata_code, ''),
+				COALESCE(description, ''), COALESCE(timezone, ''), created_at, updated_at
+			  FROM cities WHERE city_id = $1`
+
+	var city models.City
+	err := r.db.QueryRow(query, cityID).Scan(
+		&city.CityID,
+		&city.CountryID,
+		&city.Name,
+		&city.Latitude,
+		&city.Longitude,
+		&city.IataCode,
+		&city.Description,
+		&city.Timezone,
+		&city.CreatedAt,
+		&city.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("Failed to fetch city", "city_id", cityID, "error", err)
+		return nil, fmt.Errorf("failed to fetch city %d: %w", cityID, err)
+	}
+	return &city, nil
+}
+
 type CityLocation struct {
 	ID        int
 	Name      string
@@ -96,14 +127,37 @@ func (r *CityRepository) GetAllCityLocations() ([]CityLocation, error) {
 	return locations, nil
 }
 
-func (r *CityRepository) GetAllCities() ([]models.City, error) {
+var cityList = listSpec[models.City]{
+	idExpr: "c.city_id",
+	id:     func(c models.City) int { return c.CityID },
+	sorts: map[string]sortKey[models.City]{
+		"id":   {"c.city_id", func(c models.City) interface{} { return c.CityID }},
+		"name": {"c.name", func(c models.City) interface{} { return c.Name }},
+	},
+	defaultSort: "id",
+}
+
+// GetAllCities returns one page of cities matching the filter. Sort keys: id, name.
+func (r *CityRepository) GetAllCities(filter models.ResourceFilter) (models.Page[models.City], error) {
+	var where whereBuilder
+	if filter.CountryID > 0 {
+		where.add("c.country_id = ?", filter.CountryID)
+	}
+	if filter.BBox != nil {
+		where.addBoundingBox(*filter.BBox, "c")
+	}
+	plan, err := cityList.plan(&where, filter.ListParams)
+	if err != nil {
+		return models.Page[models.City]{}, err
+	}
+
 	query := `SELECT city_id,country_id,name,latitude,longitude,description,created_at,updated_at
-			  FROM cities;`
+			  FROM cities c` + where.clause() + plan.suffix()
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, where.args...)
 	if err != nil {
 		slog.Error("Failed to fetch all cities", "error", err)
-		return nil, fmt.Errorf("failed to fetch all cities: %w", err)
+		return models.Page[models.City]{}, fmt.Errorf("failed to fetch all cities: %w", err)
 	}
 	defer rows.Close()
 
@@ -127,9 +181,9 @@ func (r *CityRepository) GetAllCities() ([]models.City, error) {
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error after scanning city rows: %w", err)
+		return models.Page[models.City]{}, fmt.Errorf("error after scanning city rows: %w", err)
 	}
-	return cities, nil
+	return plan.page(cities), nil
 }
 
 func (r *CityRepository) UpsertCityIata(cityID int, iataCode string) error {
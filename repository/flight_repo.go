@@ -67,14 +67,48 @@ func (r *FlightRepository) Upsert(flight *models.Flight) (int, error) {
 	return flightID, nil
 }
 
-func (r *FlightRepository) GetAllFlights() ([]models.Flight, error) {
+var flightList = listSpec[models.Flight]{
+	idExpr: "f.flight_id",
+	id:     func(f models.Flight) int { return f.FlightID },
+	sorts: map[string]sortKey[models.Flight]{
+		"id":       {"f.flight_id", func(f models.Flight) interface{} { return f.FlightID }},
+		"price":    {"f.price", func(f models.Flight) interface{} { return f.Price }},
+		"duration": {"f.duration_minutes", func(f models.Flight) interface{} { return f.DurationMinutes }},
+	},
+	defaultSort: "id",
+}
+
+// GetAllFlights returns one page of flights matching the filter.
+// Sort keys: id, price, duration.
+func (r *FlightRepository) GetAllFlights(filter models.ResourceFilter) (models.Page[models.Flight], error) {
+	var where whereBuilder
+	if filter.CityID > 0 {
+		where.add("(f.from_city_id = ? OR f.to_city_id = ?)", filter.CityID, filter.CityID)
+	}
+	if filter.FromCityID > 0 {
+		where.add("f.from_city_id = ?", filter.FromCityID)
+	}
+	if filter.ToCityID > 0 {
+		where.add("f.to_city_id = ?", filter.ToCityID)
+	}
+	if filter.CountryID > 0 {
+		where.add("f.to_city_id IN (SELECT city_id FROM cities WHERE country_id = ?)", filter.CountryID)
+	}
+	if filter.MaxPrice > 0 {
+		where.add("f.price <= ?", filter.MaxPrice)
+	}
+	plan, err := flightList.plan(&where, filter.ListParams)
+	if err != nil {
+		return models.Page[models.Flight]{}, err
+	}
+
 	query := `SELECT flight_id, from_city_id, to_city_id, airline, duration_minutes, price, website, created_at, updated_at
-              FROM flights;`
+              FROM flights f` + where.clause() + plan.suffix()
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, where.args...)
 	if err != nil {
 		slog.Error("Failed to fetch all flights", "error", err)
-		return nil, fmt.Errorf("failed to fetch all flights: %w", err)
+		return models.Page[models.Flight]{}, fmt.Errorf("failed to fetch all flights: %w", err)
 	}
 	defer rows.Close()
 
@@ -102,9 +136,9 @@ func (r *FlightRepository) GetAllFlights() ([]models.Flight, error) {
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows interation error: %w", err)
+		return models.Page[models.Flight]{}, fmt.Errorf("rows interation error: %w", err)
 	}
-	return flights, nil
+	return plan.page(flights), nil
 }
 
 func (r *FlightRepository) GetBestFlightByTier(fromCityID, toCityID int, budgetMax float64, tier string) (*models.Flight, error) {
@@ -153,3 +187,33 @@ func (r *FlightRepository) GetBestFlightByTier(fromCityID, toCityID int, budgetM
 
 	return flight, nil
 }
+
+func (r *FlightRepository) GetFlightByID(flightID int) (*models.Flight, error) {
+	query := `SELECT flight_id, from_city_id, to_city_id, airline, duration_minutes, price, website
+	FROM flights
+	WHERE flight_id = $1`
+
+	flight := &models.Flight{}
+	var websiteSql sql.NullString
+	err := r.db.QueryRow(query, flightID).Scan(
+		&flight.FlightID,
+		&flight.FromCityID,
+		&flight.ToCityID,
+		&flight.Airline,
+		&flight.DurationMinutes,
+		&flight.Price,
+		&websiteSql,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			slog.Debug("Flight not found", "flight_id", flightID)
+			return nil, nil
+		}
+		slog.Error("Database error fetching flight", "flight_id", flightID, "error", err)
+		return nil, fmt.Errorf("failed to fetch flight %d: %w", flightID, err)
+	}
+
+	flight.Website = websiteSql.String
+	return flight, nil
+}
@@ -20,11 +20,13 @@ func (r *HotelRepository) Upsert(hotel *models.Hotel) (int, error) {
 	query := `INSERT INTO hotels (
         city_id, name, address, stars, rating, price_per_night,
        	website, description, 
-        created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        created_at, updated_at, latitude, longitude)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
         ON CONFLICT (name, city_id) DO UPDATE 
         SET 
             address = EXCLUDED.address,
+            latitude = COALESCE(EXCLUDED.latitude, hotels.latitude),
+            longitude = COALESCE(EXCLUDED.longitude, hotels.longitude),
             stars = EXCLUDED.stars,
             rating = EXCLUDED.rating,
             price_per_night = EXCLUDED.price_per_night,
@@ -54,6 +56,8 @@ func (r *HotelRepository) Upsert(hotel *models.Hotel) (int, error) {
 		hotel.Description,
 		hotel.CreatedAt,
 		hotel.UpdatedAt,
+		nullCoordinate(hotel.Latitude, hotel.Longitude, hotel.Latitude),
+		nullCoordinate(hotel.Latitude, hotel.Longitude, hotel.Longitude),
 	).Scan(&hotelID)
 
 	if err != nil {
@@ -69,17 +73,82 @@ func (r *HotelRepository) Upsert(hotel *models.Hotel) (int, error) {
 	return hotelID, nil
 }
 
-func (r *HotelRepository) GetAllHotels() ([]models.Hotel, error) {
+// Update overwrites the editable fields of a hotel. It reports false when the hotel
+// does not exist.
+func (r *HotelRepository) Update(hotel *models.Hotel) (bool, error) {
+	query := `UPDATE hotels
+        SET name = $2, address = $3, stars = $4, rating = $5, price_per_night = $6, website = $7,
+            description = $8, latitude = $9, longitude = $10, updated_at = NOW() AT TIME ZONE 'Asia/Yerevan'
+        WHERE hotel_id = $1
+        RETURNING city_id, updated_at`
+
+	err := r.db.QueryRow(query,
+		hotel.HotelID,
+		hotel.Name,
+		hotel.Address,
+		hotel.Stars,
+		hotel.Rating,
+		hotel.PricePerNight,
+		hotel.Website,
+		hotel.Description,
+		nullCoordinate(hotel.Latitude, hotel.Longitude, hotel.Latitude),
+		nullCoordinate(hotel.Latitude, hotel.Longitude, hotel.Longitude),
+	).Scan(&hotel.CityID, &hotel.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, ErrCatalogConflict
+		}
+		slog.Error("Failed to update hotel", "hotel_id", hotel.HotelID, "error", err)
+		return false, fmt.Errorf("failed to update hotel %d: %w", hotel.HotelID, err)
+	}
+	return true, nil
+}
+
+var hotelList = listSpec[models.Hotel]{
+	idExpr: "h.hotel_id",
+	id:     func(h models.Hotel) int { return h.HotelID },
+	sorts: map[string]sortKey[models.Hotel]{
+		"id":     {"h.hotel_id", func(h models.Hotel) interface{} { return h.HotelID }},
+		"name":   {"h.name", func(h models.Hotel) interface{} { return h.Name }},
+		"rating": {"COALESCE(h.rating, 0)", func(h models.Hotel) interface{} { return h.Rating }},
+		"price":  {"COALESCE(h.price_per_night, 0)", func(h models.Hotel) interface{} { return h.PricePerNight }},
+		"stars":  {"COALESCE(h.stars, 0)", func(h models.Hotel) interface{} { return h.Stars }},
+	},
+	defaultSort: "id",
+}
+
+// GetAllHotels returns one page of hotels matching the filter.
+// Sort keys: id, name, rating, price, stars.
+func (r *HotelRepository) GetAllHotels(filter models.ResourceFilter) (models.Page[models.Hotel], error) {
+	var where whereBuilder
+	where.addLocationFilter(filter, "h")
+	if filter.MinRating > 0 {
+		where.add("h.rating >= ?", filter.MinRating)
+	}
+	if filter.MaxPrice > 0 {
+		where.add("h.price_per_night <= ?", filter.MaxPrice)
+	}
+	if filter.Stars > 0 {
+		where.add("h.stars = ?", filter.Stars)
+	}
+	plan, err := hotelList.plan(&where, filter.ListParams)
+	if err != nil {
+		return models.Page[models.Hotel]{}, err
+	}
+
 	query := `SELECT 
                 hotel_id, city_id, name, address, stars, rating, price_per_night, 
                 website, description, 
-                created_at, updated_at
-              FROM hotels;`
+                created_at, updated_at, latitude, longitude
+              FROM hotels h` + where.clause() + plan.suffix()
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, where.args...)
 	if err != nil {
 		slog.Error("Failed to fetch all hotels", "error", err)
-		return nil, fmt.Errorf("failed to fetch all hotels: %w", err)
+		return models.Page[models.Hotel]{}, fmt.Errorf("failed to fetch all hotels: %w", err)
 	}
 	defer rows.Close()
 
@@ -89,11 +158,13 @@ func (r *HotelRepository) GetAllHotels() ([]models.Hotel, error) {
 		var starsSql sql.NullInt32
 		var ratingSql, priceSql sql.NullFloat64
 		var websiteSql, descriptionSql sql.NullString
+		var latitudeSql, longitudeSql sql.NullFloat64
 
 		if err := rows.Scan(
 			&h.HotelID, &h.CityID, &h.Name, &h.Address,
 			&starsSql, &ratingSql, &priceSql,
 			&websiteSql, &descriptionSql, &h.CreatedAt, &h.UpdatedAt,
+			&latitudeSql, &longitudeSql,
 		); err != nil {
 			slog.Warn("Error scanning hotel row", "error", err)
 			continue
@@ -104,10 +175,15 @@ func (r *HotelRepository) GetAllHotels() ([]models.Hotel, error) {
 		h.PricePerNight = priceSql.Float64
 		h.Website = websiteSql.String
 		h.Description = descriptionSql.String
+		h.Latitude = latitudeSql.Float64
+		h.Longitude = longitudeSql.Float64
 		hotels = append(hotels, h)
 	}
 
-	return hotels, nil
+	if err := rows.Err(); err != nil {
+		return models.Page[models.Hotel]{}, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return plan.page(hotels), nil
 }
 
 func (r *HotelRepository) GetBestHotelByTier(cityID int, budgetMax float64, tier string) (*models.Hotel, error) {
@@ -121,23 +197,24 @@ func (r *HotelRepository) GetBestHotelByTier(cityID int, budgetMax float64, tier
 	case "Economy":
 		orderBy = "price_per_night ASC"
 	case "Balanced":
-		orderBy = "rating DESC, price_per_night DESC"
+		orderBy = "blended_rating DESC, price_per_night DESC"
 		filter = "AND price_per_night <= $2 * 0.6"
 	case "Luxury":
-		orderBy = "rating DESC, price_per_night DESC"
+		orderBy = "blended_rating DESC, price_per_night DESC"
 	default:
-		orderBy = "rating DESC"
+		orderBy = "blended_rating DESC"
 	}
 
 	query := fmt.Sprintf(`
     SELECT 
-        hotel_id, city_id, name, address, stars, rating, price_per_night, 
-        website, description
+        hotel_id, city_id, name, address, stars, COALESCE(rating, 0), blended_rating, review_count, price_per_night, 
+        website, description, latitude, longitude
     FROM hotels 
     WHERE city_id = $1 AND price_per_night <= $2  %s
     ORDER BY %s
     LIMIT 1`, filter, orderBy)
 
+	var latitudeSql, longitudeSql sql.NullFloat64
 	err := r.db.QueryRow(query, cityID, budgetMax).Scan(
 		&hotel.HotelID,
 		&hotel.CityID,
@@ -145,9 +222,13 @@ func (r *HotelRepository) GetBestHotelByTier(cityID int, budgetMax float64, tier
 		&hotel.Address,
 		&hotel.Stars,
 		&hotel.Rating,
+		&hotel.BlendedRating,
+		&hotel.ReviewCount,
 		&hotel.PricePerNight,
 		&hotel.Website,
 		&hotel.Description,
+		&latitudeSql,
+		&longitudeSql,
 	)
 
 	if err != nil {
@@ -159,9 +240,54 @@ func (r *HotelRepository) GetBestHotelByTier(cityID int, budgetMax float64, tier
 		return nil, fmt.Errorf("failed to find hotel: %w", err)
 	}
 
+	hotel.Latitude = latitudeSql.Float64
+	hotel.Longitude = longitudeSql.Float64
 	return hotel, nil
 }
 
+func (r *HotelRepository) GetHotelByID(hotelID int) (*models.Hotel, error) {
+	query := `
+    SELECT 
+        hotel_id, city_id, name, address, stars, rating, price_per_night, 
+        website, description, latitude, longitude
+    FROM hotels 
+    WHERE hotel_id = $1`
+
+	hotel := &models.Hotel{}
+	var latitudeSql, longitudeSql sql.NullFloat64
+	err := r.db.QueryRow(query, hotelID).Scan(
+		&hotel.HotelID,
+		&hotel.CityID,
+		&hotel.Name,
+		&hotel.Address,
+		&hotel.Stars,
+		&hotel.Rating,
+		&hotel.PricePerNight,
+		&hotel.Website,
+		&hotel.Description,
+		&latitudeSql,
+		&longitudeSql,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			slog.Debug("Hotel not found", "hotel_id", hotelID)
+			return nil, nil
+		}
+		slog.Error("Database error fetching hotel", "hotel_id", hotelID, "error", err)
+		return nil, fmt.Errorf("failed to fetch hotel %d: %w", hotelID, err)
+	}
+
+	hotel.Latitude = latitudeSql.Float64
+	hotel.Longitude = longitudeSql.Float64
+	return hotel, nil
+}
+
+// nullCoordinate stores 0,0 (the "unknown" value from the seeders) as NULL.
+func nullCoordinate(lat, lon, value float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: value, Valid: lat != 0 || lon != 0}
+}
+
 func (r *HotelRepository) GetVisitedHotels(userID int) ([]models.Hotel, error) {
 	query := `
         SELECT DISTINCT 
@@ -57,8 +57,7 @@ func (r *ItineraryActivitiesRepository) Insert(tx *sql.Tx, activity *models.Itin
 	return activityID, nil
 }
 
-func (r *ItineraryActivitiesRepository) GetActivitiesByItineraryID(itineraryID int) ([]*models.ItineraryActivity, error) {
-	query := `
+const activitySelect = `
 		SELECT
 			ia.activity_id,
             ia.itinerary_id,
@@ -98,12 +97,55 @@ func (r *ItineraryActivitiesRepository) GetActivitiesByItineraryID(itineraryID i
 				WHEN ia.activity_type = 'attraction' THEN COALESCE(a.rating, 0)
 				WHEN ia.activity_type = 'restaurant' THEN COALESCE(r.rating, 0)
 				ELSE 0
-			END AS entity_rating
+			END AS entity_rating,
+			CASE
+				WHEN ia.activity_type = 'hotel'      THEN COALESCE(h.latitude, 0)
+				WHEN ia.activity_type = 'attraction' THEN COALESCE(a.latitude, 0)
+				WHEN ia.activity_type = 'restaurant' THEN COALESCE(r.latitude, 0)
+				ELSE 0
+			END AS entity_latitude,
+			CASE
+				WHEN ia.activity_type = 'hotel'      THEN COALESCE(h.longitude, 0)
+				WHEN ia.activity_type = 'attraction' THEN COALESCE(a.longitude, 0)
+				WHEN ia.activity_type = 'restaurant' THEN COALESCE(r.longitude, 0)
+				ELSE 0
+			END AS entity_longitude
 		FROM itinerary_activities ia
 		LEFT JOIN hotels      h ON ia.hotel_id      = h.hotel_id
 		LEFT JOIN attractions a ON ia.attraction_id = a.attraction_id
 		LEFT JOIN restaurants r ON ia.restaurant_id = r.restaurant_id
-		LEFT JOIN flights     f ON ia.flight_id     = f.flight_id
+		LEFT JOIN flights     f ON ia.flight_id     = f.flight_id`
+
+func scanActivity(row rowScanner) (*models.ItineraryActivity, error) {
+	activity := &models.ItineraryActivity{}
+	err := row.Scan(
+		&activity.ActivityID,
+		&activity.ItineraryID,
+		&activity.ActivityType,
+		&activity.HotelID,
+		&activity.AttractionID,
+		&activity.RestaurantID,
+		&activity.FlightID,
+		&activity.OrderNumber,
+		&activity.StartTime,
+		&activity.EndTime,
+		&activity.Notes,
+		&activity.CreatedAt,
+		&activity.EntityName,
+		&activity.EntityDetail,
+		&activity.EntityExtra,
+		&activity.EntityRating,
+		&activity.EntityLatitude,
+		&activity.EntityLongitude,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+func (r *ItineraryActivitiesRepository) GetActivitiesByItineraryID(itineraryID int) ([]*models.ItineraryActivity, error) {
+	query := activitySelect + `
 		WHERE ia.itinerary_id = $1
 		ORDER BY ia.order_number ASC`
 
@@ -116,27 +158,7 @@ func (r *ItineraryActivitiesRepository) GetActivitiesByItineraryID(itineraryID i
 
 	var activities []*models.ItineraryActivity
 	for rows.Next() {
-		activity := &models.ItineraryActivity{}
-
-		err := rows.Scan(
-			&activity.ActivityID,
-			&activity.ItineraryID,
-			&activity.ActivityType,
-			&activity.HotelID,
-			&activity.AttractionID,
-			&activity.RestaurantID,
-			&activity.FlightID,
-			&activity.OrderNumber,
-			&activity.StartTime,
-			&activity.EndTime,
-			&activity.Notes,
-			&activity.CreatedAt,
-			&activity.EntityName,
-			&activity.EntityDetail,
-			&activity.EntityExtra,
-			&activity.EntityRating,
-		)
-
+		activity, err := scanActivity(rows)
 		if err != nil {
 			slog.Warn("Error scanning itinerary activity row", "error", err)
 			continue
@@ -152,3 +174,157 @@ func (r *ItineraryActivitiesRepository) GetActivitiesByItineraryID(itineraryID i
 	slog.Debug("Fetched itinerary activities", "count", len(activities), "itinerary_id", itineraryID)
 	return activities, nil
 }
+
+// GetByID returns nil when the activity does not exist.
+func (r *ItineraryActivitiesRepository) GetByID(activityID int64) (*models.ItineraryActivity, error) {
+	query := activitySelect + `
+		WHERE ia.activity_id = $1`
+
+	activity, err := scanActivity(r.db.QueryRow(query, activityID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("Error fetching itinerary activity", "activity_id", activityID, "error", err)
+		return nil, fmt.Errorf("error fetching itinerary activity %d: %w", activityID, err)
+	}
+	return activity, nil
+}
+
+// GetForUpdate re-reads an activity inside a transaction and locks its row. It returns nil
+// when the activity no longer exists.
+func (r *ItineraryActivitiesRepository) GetForUpdate(tx *sql.Tx, activityID int64) (*models.ItineraryActivity, error) {
+	query := activitySelect + `
+		WHERE ia.activity_id = $1
+		FOR UPDATE OF ia`
+
+	activity, err := scanActivity(tx.QueryRow(query, activityID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock itinerary activity %d: %w", activityID, err)
+	}
+	return activity, nil
+}
+
+// GetTripIDByActivityID returns 0 when the activity does not exist.
+func (r *ItineraryActivitiesRepository) GetTripIDByActivityID(activityID int64) (int, error) {
+	query := `
+		SELECT ti.trip_id
+		FROM itinerary_activities ia
+		JOIN trip_itinerary ti ON ia.itinerary_id = ti.itinerary_id
+		WHERE ia.activity_id = $1`
+
+	var tripID int
+	if err := r.db.QueryRow(query, activityID).Scan(&tripID); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to resolve trip of activity %d: %w", activityID, err)
+	}
+	return tripID, nil
+}
+
+// HasOverlap reports whether another activity of the day overlaps [start, end).
+func (r *ItineraryActivitiesRepository) HasOverlap(tx *sql.Tx, itineraryID int64, start, end time.Time, excludeID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM itinerary_activities
+			WHERE itinerary_id = $1 AND activity_id <> $4
+			  AND start_time < $3 AND end_time > $2
+		)`
+
+	var overlap bool
+	if err := tx.QueryRow(query, itineraryID, start, end, excludeID).Scan(&overlap); err != nil {
+		return false, fmt.Errorf("failed to check time slot: %w", err)
+	}
+	return overlap, nil
+}
+
+// UpdatePlacement moves an activity to a day and time slot.
+func (r *ItineraryActivitiesRepository) UpdatePlacement(tx *sql.Tx, activityID, itineraryID int64, start, end time.Time) error {
+	query := `UPDATE itinerary_activities SET itinerary_id = $1, start_time = $2, end_time = $3 WHERE activity_id = $4`
+
+	if _, err := tx.Exec(query, itineraryID, start, end, activityID); err != nil {
+		slog.Error("Failed to move itinerary activity", "activity_id", activityID, "error", err)
+		return fmt.Errorf("failed to move activity: %w", err)
+	}
+	return nil
+}
+
+// UpdateEntity points an activity at another attraction or restaurant.
+func (r *ItineraryActivitiesRepository) UpdateEntity(tx *sql.Tx, activity *models.ItineraryActivity) error {
+	query := `UPDATE itinerary_activities
+              SET activity_type = $1, attraction_id = $2, restaurant_id = $3, notes = $4
+              WHERE activity_id = $5`
+
+	_, err := tx.Exec(query, activity.ActivityType, activity.AttractionID, activity.RestaurantID, activity.Notes, activity.ActivityID)
+	if err != nil {
+		slog.Error("Failed to replace itinerary activity", "activity_id", activity.ActivityID, "error", err)
+		return fmt.Errorf("failed to replace activity: %w", err)
+	}
+	return nil
+}
+
+func (r *ItineraryActivitiesRepository) Delete(tx *sql.Tx, activityID int64) error {
+	if _, err := tx.Exec(`DELETE FROM itinerary_activities WHERE activity_id = $1`, activityID); err != nil {
+		slog.Error("Failed to delete itinerary activity", "activity_id", activityID, "error", err)
+		return fmt.Errorf("failed to delete activity: %w", err)
+	}
+	return nil
+}
+
+// DeleteByItineraryID clears every activity of a day.
+func (r *ItineraryActivitiesRepository) DeleteByItineraryID(tx *sql.Tx, itineraryID int64) error {
+	if _, err := tx.Exec(`DELETE FROM itinerary_activities WHERE itinerary_id = $1`, itineraryID); err != nil {
+		slog.Error("Failed to clear itinerary day", "itinerary_id", itineraryID, "error", err)
+		return fmt.Errorf("failed to clear activities of day %d: %w", itineraryID, err)
+	}
+	return nil
+}
+
+// Renumber rewrites order_number of a day as 0..n-1 in chronological order.
+func (r *ItineraryActivitiesRepository) Renumber(tx *sql.Tx, itineraryID int64) error {
+	query := `
+		UPDATE itinerary_activities ia
+		SET order_number = o.position - 1
+		FROM (
+			SELECT activity_id, ROW_NUMBER() OVER (ORDER BY start_time, order_number, activity_id) AS position
+			FROM itinerary_activities
+			WHERE itinerary_id = $1
+		) o
+		WHERE ia.activity_id = o.activity_id`
+
+	if _, err := tx.Exec(query, itineraryID); err != nil {
+		slog.Error("Failed to renumber itinerary day", "itinerary_id", itineraryID, "error", err)
+		return fmt.Errorf("failed to renumber activities: %w", err)
+	}
+	return nil
+}
+
+// GetAttractionIDsByTripID lists the attractions already scheduled in the trip, ignoring the
+// day excludeItineraryID (0 to include every day).
+func (r *ItineraryActivitiesRepository) GetAttractionIDsByTripID(tripID int, excludeItineraryID int64) ([]int, error) {
+	query := `
+		SELECT DISTINCT ia.attraction_id
+		FROM itinerary_activities ia
+		JOIN trip_itinerary ti ON ia.itinerary_id = ti.itinerary_id
+		WHERE ti.trip_id = $1 AND ia.itinerary_id <> $2 AND ia.attraction_id IS NOT NULL`
+
+	rows, err := r.db.Query(query, tripID, excludeItineraryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scheduled attractions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"travel-planning/models"
+
+	"github.com/lib/pq"
+)
+
+const kmPerDegreeLat = 111.045
+
+// nearbyBounds is the lat/lon box enclosing a radius. Longitude is split into two ranges
+// so boxes crossing the antimeridian still use the coordinate index; the second range is
+// empty otherwise.
+type nearbyBounds struct {
+	minLat, maxLat float64
+	lon            [2][2]float64
+}
+
+func boundsAround(lat, lon, radiusKm float64) nearbyBounds {
+	latDelta := radiusKm / kmPerDegreeLat
+	b := nearbyBounds{
+		minLat: math.Max(lat-latDelta, -90),
+		maxLat: math.Min(lat+latDelta, 90),
+		lon:    [2][2]float64{{-180, 180}, {1, 0}},
+	}
+
+	cosLat := math.Cos(lat * math.Pi / 180)
+	if b.minLat == -90 || b.maxLat == 90 || cosLat < 0.01 {
+		return b // the circle reaches a pole: every longitude is in range
+	}
+	lonDelta := radiusKm / (kmPerDegreeLat * cosLat)
+	if lonDelta >= 180 {
+		return b
+	}
+
+	west, east := lon-lonDelta, lon+lonDelta
+	switch {
+	case west < -180:
+		b.lon = [2][2]float64{{-180, east}, {west + 360, 180}}
+	case east > 180:
+		b.lon = [2][2]float64{{west, 180}, {-180, east - 360}}
+	default:
+		b.lon = [2][2]float64{{west, east}, {1, 0}}
+	}
+	return b
+}
+
+// Nearby returns attractions, hotels and restaurants within the radius, closest first.
+// The bounding box prunes candidates on the coordinate indexes; haversine then trims the corners.
+func (r *SearchRepository) Nearby(q models.NearbyQuery) ([]models.NearbyPlace, error) {
+	b := boundsAround(q.Latitude, q.Longitude, q.RadiusKm)
+	inBox := func(alias string) string {
+		return fmt.Sprintf(`%[1]s.latitude BETWEEN $4 AND $5
+			AND (%[1]s.longitude BETWEEN $6 AND $7 OR %[1]s.longitude BETWEEN $8 AND $9)`, alias)
+	}
+
+	query := `
+		WITH places AS (
+			SELECT 'attraction' AS entity_type, a.attraction_id AS entity_id, a.name, a.category AS detail,
+			       a.city_id, a.latitude, a.longitude, COALESCE(a.rating, 0) AS rating
+			FROM attractions a
+			WHERE 'attraction' = ANY($3) AND ` + inBox("a") + `
+			UNION ALL
+			SELECT 'hotel', h.hotel_id, h.name,
+			       CASE WHEN h.stars > 0 THEN h.stars || '-star hotel' ELSE 'Hotel' END,
+			       h.city_id, h.latitude, h.longitude, COALESCE(h.rating, 0)
+			FROM hotels h
+			WHERE 'hotel' = ANY($3) AND ` + inBox("h") + `
+			UNION ALL
+			SELECT 'restaurant', r.restaurant_id, r.name, r.cuisine,
+			       r.city_id, r.latitude, r.longitude, COALESCE(r.rating, 0)
+			FROM restaurants r
+			WHERE 'restaurant' = ANY($3) AND ` + inBox("r") + `
+		),
+		measured AS (
+			SELECT p.*, ` + haversineKm("p.latitude", "p.longitude", "$1::float8", "$2::float8") + ` AS distance_km
+			FROM places p
+		)
+		SELECT m.entity_type, m.entity_id, m.name, COALESCE(m.detail, ''), m.city_id, c.name,
+		       m.latitude, m.longitude, m.rating, m.distance_km
+		FROM measured m
+		JOIN cities c ON c.city_id = m.city_id
+		WHERE m.distance_km <= $10
+		ORDER BY m.distance_km, m.entity_type, m.entity_id
+		LIMIT $11`
+
+	rows, err := r.db.Query(query,
+		q.Latitude, q.Longitude, pq.Array(q.Types),
+		b.minLat, b.maxLat, b.lon[0][0], b.lon[0][1], b.lon[1][0], b.lon[1][1],
+		q.RadiusKm, q.Limit,
+	)
+	if err != nil {
+		slog.Error("Database error in nearby search", "lat", q.Latitude, "lon", q.Longitude, "error", err)
+		return nil, fmt.Errorf("failed to search nearby places: %w", err)
+	}
+	defer rows.Close()
+
+	places := []models.NearbyPlace{}
+	for rows.Next() {
+		var p models.NearbyPlace
+		if err := rows.Scan(
+			&p.EntityType, &p.EntityID, &p.Name, &p.Detail, &p.CityID, &p.CityName,
+			&p.Latitude, &p.Longitude, &p.Rating, &p.DistanceKm,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan nearby place: %w", err)
+		}
+		places = append(places, p)
+	}
+	return places, rows.Err()
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"travel-planning/models"
+)
+
+var ErrInvalidListQuery = errors.New("invalid list query")
+
+// ErrCatalogConflict is returned when an edit would duplicate a place's name within its city.
+var ErrCatalogConflict = errors.New("a place with this name already exists in the city")
+
+// whereBuilder collects SQL conditions, numbering their placeholders in order.
+type whereBuilder struct {
+	conditions []string
+	args       []interface{}
+}
+
+// add appends a condition whose "?" markers are bound to values in order.
+func (b *whereBuilder) add(condition string, values ...interface{}) {
+	for _, v := range values {
+		b.args = append(b.args, v)
+		condition = strings.Replace(condition, "?", fmt.Sprintf("$%d", len(b.args)), 1)
+	}
+	b.conditions = append(b.conditions, condition)
+}
+
+func (b *whereBuilder) clause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// addLocationFilter restricts a table with city_id, latitude and longitude columns.
+func (b *whereBuilder) addLocationFilter(f models.ResourceFilter, alias string) {
+	if f.CityID > 0 {
+		b.add(alias+".city_id = ?", f.CityID)
+	}
+	if f.CountryID > 0 {
+		b.add(alias+".city_id IN (SELECT city_id FROM cities WHERE country_id = ?)", f.CountryID)
+	}
+	if f.BBox != nil {
+		b.addBoundingBox(*f.BBox, alias)
+	}
+}
+
+func (b *whereBuilder) addBoundingBox(box models.BoundingBox, alias string) {
+	b.add(alias+".latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+	if box.MinLon <= box.MaxLon {
+		b.add(alias+".longitude BETWEEN ? AND ?", box.MinLon, box.MaxLon)
+	} else {
+		b.add("("+alias+".longitude >= ? OR "+alias+".longitude <= ?)", box.MinLon, box.MaxLon)
+	}
+}
+
+// sortKey is a sortable column and how to read its value back from a row for the cursor.
+// Expressions must not be NULL, so nullable columns are wrapped in COALESCE.
+type sortKey[T any] struct {
+	expr  string
+	value func(T) interface{}
+}
+
+// listSpec describes how a table is paged. Pages are keyset based: the cursor holds the
+// sort value and ID of the last row, so rows inserted meanwhile never shift a page.
+// aliases name common orders, e.g. "newest" for "-created_at".
+type listSpec[T any] struct {
+	idExpr      string
+	id          func(T) int
+	sorts       map[string]sortKey[T]
+	aliases     map[string]string
+	defaultSort string
+}
+
+type listCursor struct {
+	Sort string      `json:"s"`
+	Key  interface{} `json:"k"`
+	ID   int         `json:"id"`
+}
+
+// listPlan is a validated request for one page. sort is the order as requested,
+// order the same with aliases resolved.
+type listPlan[T any] struct {
+	spec  listSpec[T]
+	sort  string
+	order string
+	key   sortKey[T]
+	limit int
+}
+
+// plan validates the paging options and adds the cursor condition to b.
+func (spec listSpec[T]) plan(b *whereBuilder, p models.ListParams) (*listPlan[T], error) {
+	plan := &listPlan[T]{spec: spec, sort: p.Sort, limit: p.Limit}
+	if plan.sort == "" {
+		plan.sort = spec.defaultSort
+	}
+	plan.order = plan.sort
+	if order, ok := spec.aliases[plan.sort]; ok {
+		plan.order = order
+	}
+	key, ok := spec.sorts[strings.TrimPrefix(plan.order, "-")]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidListQuery, plan.sort)
+	}
+	plan.key = key
+
+	switch {
+	case plan.limit < 0:
+		return nil, fmt.Errorf("%w: limit must be positive", ErrInvalidListQuery)
+	case plan.limit == 0:
+		plan.limit = models.DefaultListLimit
+	case plan.limit > models.MaxListLimit:
+		plan.limit = models.MaxListLimit
+	}
+
+	if p.Cursor != "" {
+		cursor, err := decodeCursor(p.Cursor)
+		if err != nil || cursor.Sort != plan.sort {
+			return nil, fmt.Errorf("%w: cursor does not belong to this listing", ErrInvalidListQuery)
+		}
+		cmp := ">"
+		if plan.descending() {
+			cmp = "<"
+		}
+		b.add(fmt.Sprintf("(%s, %s) %s (?, ?)", key.expr, spec.idExpr, cmp), cursor.Key, cursor.ID)
+	}
+	return plan, nil
+}
+
+func (p *listPlan[T]) descending() bool {
+	return strings.HasPrefix(p.order, "-")
+}
+
+// suffix orders the rows and fetches one extra to know whether another page exists.
+func (p *listPlan[T]) suffix() string {
+	dir := "ASC"
+	if p.descending() {
+		dir = "DESC"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT %d", p.key.expr, dir, p.spec.idExpr, dir, p.limit+1)
+}
+
+func (p *listPlan[T]) page(rows []T) models.Page[T] {
+	page := models.Page[T]{Data: rows, Limit: p.limit, Sort: p.sort}
+	if len(rows) > p.limit {
+		page.Data = rows[:p.limit]
+		page.HasMore = true
+		last := page.Data[p.limit-1]
+		page.NextCursor = encodeCursor(listCursor{Sort: p.sort, Key: p.key.value(last), ID: p.spec.id(last)})
+	}
+	if page.Data == nil {
+		page.Data = []T{}
+	}
+	return page
+}
+
+func encodeCursor(c listCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (listCursor, error) {
+	var c listCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	// Keep numbers as text so they bind to integer and float columns alike.
+	dec.UseNumber()
+	if err := dec.Decode(&c); err != nil {
+		return c, err
+	}
+	if num, ok := c.Key.(json.Number); ok {
+		c.Key = num.String()
+	}
+	return c, nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"travel-planning/models"
+)
+
+type testRow struct {
+	ID     int
+	Name   string
+	Rating float64
+}
+
+var testList = listSpec[testRow]{
+	idExpr: "t.id",
+	id:     func(r testRow) int { return r.ID },
+	sorts: map[string]sortKey[testRow]{
+		"id":     {"t.id", func(r testRow) interface{} { return r.ID }},
+		"name":   {"t.name", func(r testRow) interface{} { return r.Name }},
+		"rating": {"t.rating", func(r testRow) interface{} { return r.Rating }},
+	},
+	aliases:     map[string]string{"best": "-rating", "worst": "rating"},
+	defaultSort: "id",
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		cursor  listCursor
+		wantKey interface{}
+	}{
+		{"integer key", listCursor{Sort: "id", Key: 42, ID: 42}, "42"},
+		{"float key", listCursor{Sort: "-rating", Key: 4.75, ID: 7}, "4.75"},
+		{"string key", listCursor{Sort: "name", Key: "Café, \"Ø\"", ID: 3}, "Café, \"Ø\""},
+		{"alias sort", listCursor{Sort: "best", Key: 5.0, ID: 1}, "5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeCursor(tt.cursor)
+			if strings.ContainsAny(encoded, "+/=") {
+				t.Fatalf("cursor %q is not URL safe", encoded)
+			}
+			got, err := decodeCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+			if got.Sort != tt.cursor.Sort || got.ID != tt.cursor.ID || got.Key != tt.wantKey {
+				t.Errorf("decodeCursor = %+v, want sort %q, key %v, id %d", got, tt.cursor.Sort, tt.wantKey, tt.cursor.ID)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsGarbage(t *testing.T) {
+	for _, raw := range []string{"not base64!", "bm90IGpzb24", "eyJzIjoxfQ"} {
+		if _, err := decodeCursor(raw); err == nil {
+			t.Errorf("decodeCursor(%q) succeeded", raw)
+		}
+	}
+}
+
+func TestListPlan(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     models.ListParams
+		wantSort   string
+		wantSuffix string
+		wantWhere  string
+	}{
+		{
+			name:       "default sort ascending",
+			params:     models.ListParams{},
+			wantSort:   "id",
+			wantSuffix: " ORDER BY t.id ASC, t.id ASC LIMIT 51",
+		},
+		{
+			name:       "descending sort",
+			params:     models.ListParams{Sort: "-name", Limit: 10},
+			wantSort:   "-name",
+			wantSuffix: " ORDER BY t.name DESC, t.id DESC LIMIT 11",
+		},
+		{
+			name:       "alias resolves to descending order",
+			params:     models.ListParams{Sort: "best"},
+			wantSort:   "best",
+			wantSuffix: " ORDER BY t.rating DESC, t.id DESC LIMIT 51",
+		},
+		{
+			name:       "limit is capped",
+			params:     models.ListParams{Sort: "worst", Limit: 1000},
+			wantSort:   "worst",
+			wantSuffix: " ORDER BY t.rating ASC, t.id ASC LIMIT 201",
+		},
+		{
+			name:       "ascending cursor continues after the key",
+			params:     models.ListParams{Sort: "name", Cursor: encodeCursor(listCursor{Sort: "name", Key: "M", ID: 9})},
+			wantSort:   "name",
+			wantSuffix: " ORDER BY t.name ASC, t.id ASC LIMIT 51",
+			wantWhere:  " WHERE (t.name, t.id) > ($1, $2)",
+		},
+		{
+			name:       "descending alias cursor continues before the key",
+			params:     models.ListParams{Sort: "best", Cursor: encodeCursor(listCursor{Sort: "best", Key: 4.5, ID: 9})},
+			wantSort:   "best",
+			wantSuffix: " ORDER BY t.rating DESC, t.id DESC LIMIT 51",
+			wantWhere:  " WHERE (t.rating, t.id) < ($1, $2)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var where whereBuilder
+			plan, err := testList.plan(&where, tt.params)
+			if err != nil {
+				t.Fatalf("plan: %v", err)
+			}
+			if plan.sort != tt.wantSort {
+				t.Errorf("sort = %q, want %q", plan.sort, tt.wantSort)
+			}
+			if got := plan.suffix(); got != tt.wantSuffix {
+				t.Errorf("suffix = %q, want %q", got, tt.wantSuffix)
+			}
+			if got := where.clause(); got != tt.wantWhere {
+				t.Errorf("where = %q, want %q", got, tt.wantWhere)
+			}
+		})
+	}
+}
+
+func TestListPlanRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		params models.ListParams
+	}{
+		{"unknown sort", models.ListParams{Sort: "price"}},
+		{"negative limit", models.ListParams{Limit: -1}},
+		{"malformed cursor", models.ListParams{Cursor: "%%%"}},
+		{"cursor of another sort", models.ListParams{Sort: "name", Cursor: encodeCursor(listCursor{Sort: "-name", Key: "M", ID: 9})}},
+		{"cursor of the aliased order", models.ListParams{Sort: "best", Cursor: encodeCursor(listCursor{Sort: "-rating", Key: 4.5, ID: 9})}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var where whereBuilder
+			if _, err := testList.plan(&where, tt.params); !errors.Is(err, ErrInvalidListQuery) {
+				t.Errorf("plan error = %v, want ErrInvalidListQuery", err)
+			}
+		})
+	}
+}
+
+func TestListPlanPage(t *testing.T) {
+	rows := []testRow{{1, "A", 5}, {2, "B", 4}, {3, "C", 3}}
+	var where whereBuilder
+
+	plan, err := testList.plan(&where, models.ListParams{Sort: "best", Limit: 2})
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	page := plan.page(rows)
+	if len(page.Data) != 2 || !page.HasMore || page.Sort != "best" {
+		t.Fatalf("page = %+v, want 2 rows with more", page)
+	}
+	cursor, err := decodeCursor(page.NextCursor)
+	if err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	if cursor.Sort != "best" || cursor.Key != "4" || cursor.ID != 2 {
+		t.Errorf("next cursor = %+v, want the last row of the page", cursor)
+	}
+
+	last := plan.page(rows[:1])
+	if last.HasMore || last.NextCursor != "" {
+		t.Errorf("last page = %+v, want no next cursor", last)
+	}
+	if empty := plan.page(nil); empty.Data == nil {
+		t.Error("empty page has nil data, want []")
+	}
+}
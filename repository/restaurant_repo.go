@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"time"
 	"travel-planning/models"
+
+	"github.com/lib/pq"
 )
 
 type RestaurantRepository struct {
@@ -71,16 +73,78 @@ func (r *RestaurantRepository) Upsert(restaurant *models.Restaurant) (int, error
 	return restaurantID, nil
 }
 
-func (r *RestaurantRepository) GetAllRestaurants() ([]models.Restaurant, error) {
+// Update overwrites the editable fields of a restaurant. It reports false when the
+// restaurant does not exist.
+func (r *RestaurantRepository) Update(restaurant *models.Restaurant) (bool, error) {
+	query := `UPDATE restaurants
+    SET name = $2, cuisine = $3, latitude = $4, longitude = $5, rating = $6, price_range = $7,
+        website = $8, updated_at = NOW() AT TIME ZONE 'Asia/Yerevan'
+    WHERE restaurant_id = $1
+    RETURNING city_id, updated_at`
+
+	err := r.db.QueryRow(query,
+		restaurant.RestaurantID,
+		restaurant.Name,
+		restaurant.Cuisine,
+		restaurant.Latitude,
+		restaurant.Longitude,
+		restaurant.Rating,
+		restaurant.PriceRange,
+		restaurant.Website,
+	).Scan(&restaurant.CityID, &restaurant.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, ErrCatalogConflict
+		}
+		slog.Error("Failed to update restaurant", "restaurant_id", restaurant.RestaurantID, "error", err)
+		return false, fmt.Errorf("failed to update restaurant %d: %w", restaurant.RestaurantID, err)
+	}
+	return true, nil
+}
+
+var restaurantList = listSpec[models.Restaurant]{
+	idExpr: "r.restaurant_id",
+	id:     func(r models.Restaurant) int { return r.RestaurantID },
+	sorts: map[string]sortKey[models.Restaurant]{
+		"id":     {"r.restaurant_id", func(r models.Restaurant) interface{} { return r.RestaurantID }},
+		"name":   {"r.name", func(r models.Restaurant) interface{} { return r.Name }},
+		"rating": {"COALESCE(r.rating, 0)", func(r models.Restaurant) interface{} { return r.Rating }},
+	},
+	defaultSort: "id",
+}
+
+// GetAllRestaurants returns one page of restaurants matching the filter.
+// Sort keys: id, name, rating.
+func (r *RestaurantRepository) GetAllRestaurants(filter models.ResourceFilter) (models.Page[models.Restaurant], error) {
+	var where whereBuilder
+	where.addLocationFilter(filter, "r")
+	if filter.Cuisine != "" {
+		// OSM cuisines are lists such as "italian;pizza".
+		where.add("r.cuisine ILIKE ?", "%"+filter.Cuisine+"%")
+	}
+	if filter.PriceRange != "" {
+		where.add("r.price_range = ?", filter.PriceRange)
+	}
+	if filter.MinRating > 0 {
+		where.add("r.rating >= ?", filter.MinRating)
+	}
+	plan, err := restaurantList.plan(&where, filter.ListParams)
+	if err != nil {
+		return models.Page[models.Restaurant]{}, err
+	}
+
 	query := `SELECT 
                 restaurant_id, city_id, name, cuisine, latitude, longitude, rating, price_range, 
                 website, created_at, updated_at
-            FROM restaurants;`
+            FROM restaurants r` + where.clause() + plan.suffix()
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, where.args...)
 	if err != nil {
 		slog.Error("Failed to fetch all restaurants", "error", err)
-		return nil, fmt.Errorf("failed to fetch all restaurants: %w", err)
+		return models.Page[models.Restaurant]{}, fmt.Errorf("failed to fetch all restaurants: %w", err)
 	}
 	defer rows.Close()
 
@@ -109,7 +173,10 @@ func (r *RestaurantRepository) GetAllRestaurants() ([]models.Restaurant, error)
 		restaurants = append(restaurants, r)
 	}
 
-	return restaurants, nil
+	if err := rows.Err(); err != nil {
+		return models.Page[models.Restaurant]{}, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return plan.page(restaurants), nil
 }
 
 func (r *RestaurantRepository) GetBestRestaurantByTier(cityID int, tier string) ([]models.Restaurant, error) {
@@ -128,10 +195,11 @@ func (r *RestaurantRepository) GetBestRestaurantByTier(cityID int, tier string)
 	}
 
 	query := fmt.Sprintf(`
-        SELECT restaurant_id, city_id, name, cuisine, latitude, longitude, rating, price_range, website
+        SELECT restaurant_id, city_id, name, cuisine, latitude, longitude,
+               COALESCE(rating, 0), blended_rating, review_count, price_range, website
         FROM restaurants 
         WHERE city_id = $1 %s
-        ORDER BY rating DESC
+        ORDER BY blended_rating DESC
         LIMIT 15`, priceFilter)
 
 	rows, err := r.db.Query(query, cityID)
@@ -152,6 +220,8 @@ func (r *RestaurantRepository) GetBestRestaurantByTier(cityID int, tier string)
 			&res.Latitude,
 			&res.Longitude,
 			&res.Rating,
+			&res.BlendedRating,
+			&res.ReviewCount,
 			&res.PriceRange,
 			&res.Website); err != nil {
 			slog.Warn("Skipping restaurant row due to scan error", "error", err)
@@ -199,3 +269,60 @@ func (r *RestaurantRepository) GetVisitedRestaurants(userID int) ([]models.Resta
 	}
 	return restaurants, nil
 }
+
+// GetByID returns nil when the restaurant does not exist.
+func (r *RestaurantRepository) GetByID(restaurantID int) (*models.Restaurant, error) {
+	query := `SELECT restaurant_id, city_id, name, COALESCE(cuisine, ''), COALESCE(latitude, 0), COALESCE(longitude, 0),
+                COALESCE(rating, 0), COALESCE(price_range, ''), COALESCE(website, '')
+              FROM restaurants WHERE restaurant_id = $1`
+
+	var res models.Restaurant
+	err := r.db.QueryRow(query, restaurantID).Scan(
+		&res.RestaurantID, &res.CityID, &res.Name, &res.Cuisine, &res.Latitude, &res.Longitude,
+		&res.Rating, &res.PriceRange, &res.Website,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("Database error in restaurant GetByID", "restaurant_id", restaurantID, "error", err)
+		return nil, fmt.Errorf("failed to fetch restaurant %d: %w", restaurantID, err)
+	}
+	return &res, nil
+}
+
+// GetNearby returns restaurants of the city closest to the point, skipping excludeIDs.
+func (r *RestaurantRepository) GetNearby(cityID int, lat, lon float64, excludeIDs []int, limit int) ([]models.Restaurant, error) {
+	query := `
+        SELECT restaurant_id, city_id, name, COALESCE(cuisine, ''), latitude, longitude,
+               COALESCE(rating, 0), COALESCE(price_range, ''), COALESCE(website, '')
+        FROM restaurants
+        WHERE city_id = $1 AND latitude IS NOT NULL AND longitude IS NOT NULL
+          AND NOT (restaurant_id = ANY($4))
+        ORDER BY power(latitude - $2, 2) + power((longitude - $3) * cos(radians($2)), 2) ASC
+        LIMIT $5`
+
+	if excludeIDs == nil {
+		excludeIDs = []int{}
+	}
+	rows, err := r.db.Query(query, cityID, lat, lon, pq.Array(excludeIDs), limit)
+	if err != nil {
+		slog.Error("Database error in restaurant GetNearby", "city_id", cityID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []models.Restaurant
+	for rows.Next() {
+		var res models.Restaurant
+		if err := rows.Scan(
+			&res.RestaurantID, &res.CityID, &res.Name, &res.Cuisine, &res.Latitude, &res.Longitude,
+			&res.Rating, &res.PriceRange, &res.Website,
+		); err != nil {
+			slog.Warn("Skipping restaurant row due to scan error", "error", err)
+			continue
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
@@ -2,12 +2,28 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 	"travel-planning/models"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrReviewNotFound  = errors.New("review not found")
+	ErrDuplicateReview = errors.New("you have already reviewed this place")
 )
 
+// uniqueViolation is the Postgres error code for a unique index conflict.
+const uniqueViolation = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
 type ReviewRepository struct {
 	db *sql.DB
 }
@@ -18,11 +34,13 @@ func NewReviewRepository(db *sql.DB) *ReviewRepository {
 	}
 }
 
+// Insert stores the review and refreshes the rating aggregates of the reviewed place
+// in the same transaction.
 func (r *ReviewRepository) Insert(review *models.Review) (int, error) {
 	query := `INSERT INTO reviews (
-	    user_id, entity_type, entity_id, rating, comment, review_date, created_at
+	    user_id, entity_type, entity_id, rating, comment, review_date, created_at, verified, status, flags
 	)
-	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	RETURNING review_id;`
 
 	var reviewID int
@@ -32,7 +50,13 @@ func (r *ReviewRepository) Insert(review *models.Review) (int, error) {
 		reviewDate = currTime
 	}
 
-	err := r.db.QueryRow(
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin review transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(
 		query,
 		review.UserID,
 		review.EntityType,
@@ -41,9 +65,15 @@ func (r *ReviewRepository) Insert(review *models.Review) (int, error) {
 		review.Comment,
 		reviewDate,
 		currTime,
+		review.Verified,
+		review.Status,
+		pq.Array(review.Flags),
 	).Scan(&reviewID)
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, ErrDuplicateReview
+		}
 		slog.Error("Failed to insert review",
 			"user_id", review.UserID,
 			"entity_type", review.EntityType,
@@ -53,6 +83,13 @@ func (r *ReviewRepository) Insert(review *models.Review) (int, error) {
 		return 0, fmt.Errorf("failed to insert review for user %d: %w", review.UserID, err)
 	}
 
+	if err := refreshRating(tx, review.EntityType, review.EntityID); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit review: %w", err)
+	}
+
 	slog.Debug("Review inserted successfully", "review_id", reviewID, "user_id", review.UserID)
 	return reviewID, nil
 }
@@ -60,7 +97,8 @@ func (r *ReviewRepository) Insert(review *models.Review) (int, error) {
 func (r *ReviewRepository) GetByUserID(userID int) ([]models.Review, error) {
 	query := `
         SELECT 
-            rv.review_id, rv.rating, rv.comment, rv.created_at,rv.entity_type,rv.entity_id,
+            rv.review_id, rv.rating, rv.comment, rv.created_at,rv.entity_type,rv.entity_id, rv.verified,
+            rv.status, rv.flags, rv.moderation_note, rv.updated_at,
             CASE 
                 WHEN rv.entity_type = 'hotel'      THEN COALESCE(h.name, 'Unknown Hotel')
                 WHEN rv.entity_type = 'restaurant' THEN COALESCE(res.name, 'Unknown Restaurant')
@@ -91,6 +129,11 @@ func (r *ReviewRepository) GetByUserID(userID int) ([]models.Review, error) {
 			&rev.CreatedAt,
 			&rev.EntityType,
 			&rev.EntityID,
+			&rev.Verified,
+			&rev.Status,
+			pq.Array(&rev.Flags),
+			&rev.ModerationNote,
+			&rev.UpdatedAt,
 			&rev.EntityName,
 		); err != nil {
 			slog.Warn("Error scanning review row", "user_id", userID, "error", err)
@@ -101,16 +144,409 @@ func (r *ReviewRepository) GetByUserID(userID int) ([]models.Review, error) {
 	return reviews, nil
 }
 
+var placeReviewList = listSpec[models.Review]{
+	idExpr: "rv.review_id",
+	id:     func(rv models.Review) int { return rv.ReviewID },
+	sorts: map[string]sortKey[models.Review]{
+		"created_at": {"rv.created_at", func(rv models.Review) interface{} { return rv.CreatedAt }},
+		"rating":     {"rv.rating", func(rv models.Review) interface{} { return rv.Rating }},
+	},
+	aliases: map[string]string{
+		"newest":  "-created_at",
+		"highest": "-rating",
+		"lowest":  "rating",
+	},
+	defaultSort: "newest",
+}
+
+// GetByEntity returns one page of a place's approved reviews with the reviewer's first
+// name and last initial.
+func (r *ReviewRepository) GetByEntity(entityType string, entityID int, params models.ListParams) (models.Page[models.Review], error) {
+	b := &whereBuilder{}
+	b.add("rv.entity_type = ?", entityType)
+	b.add("rv.entity_id = ?", entityID)
+	b.add("rv.status = ?", models.ReviewApproved)
+	plan, err := placeReviewList.plan(b, params)
+	if err != nil {
+		return models.Page[models.Review]{}, err
+	}
+
+	query := `
+		SELECT rv.review_id, rv.user_id, rv.entity_type, rv.entity_id, rv.rating, rv.comment,
+		       rv.review_date, rv.created_at, rv.updated_at, rv.verified, rv.status,
+		       trim(u.first_name || ' ' || left(u.last_name, 1) || CASE WHEN u.last_name <> '' THEN '.' ELSE '' END)
+		FROM reviews rv
+		JOIN users u ON u.user_id = rv.user_id` + b.clause() + plan.suffix()
+
+	rows, err := r.db.Query(query, b.args...)
+	if err != nil {
+		slog.Error("Failed to fetch reviews for place", "entity_type", entityType, "entity_id", entityID, "error", err)
+		return models.Page[models.Review]{}, fmt.Errorf("failed to fetch reviews for %s %d: %w", entityType, entityID, err)
+	}
+	defer rows.Close()
+
+	var reviews []models.Review
+	for rows.Next() {
+		var rev models.Review
+		if err := rows.Scan(
+			&rev.ReviewID,
+			&rev.UserID,
+			&rev.EntityType,
+			&rev.EntityID,
+			&rev.Rating,
+			&rev.Comment,
+			&rev.ReviewDate,
+			&rev.CreatedAt,
+			&rev.UpdatedAt,
+			&rev.Verified,
+			&rev.Status,
+			&rev.ReviewerName,
+		); err != nil {
+			return models.Page[models.Review]{}, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Page[models.Review]{}, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return plan.page(reviews), nil
+}
+
+// GetSummary counts and averages every approved review of a place.
+func (r *ReviewRepository) GetSummary(entityType string, entityID int) (models.ReviewSummary, error) {
+	query := `
+		SELECT count(*), COALESCE(avg(rating), 0),
+		       count(*) FILTER (WHERE rating = 1), count(*) FILTER (WHERE rating = 2),
+		       count(*) FILTER (WHERE rating = 3), count(*) FILTER (WHERE rating = 4),
+		       count(*) FILTER (WHERE rating = 5)
+		FROM reviews
+		WHERE entity_type = $1 AND entity_id = $2 AND status = 'approved'`
+
+	var summary models.ReviewSummary
+	var stars [5]int
+	err := r.db.QueryRow(query, entityType, entityID).Scan(
+		&summary.Count, &summary.Average, &stars[0], &stars[1], &stars[2], &stars[3], &stars[4],
+	)
+	if err != nil {
+		slog.Error("Failed to summarize reviews", "entity_type", entityType, "entity_id", entityID, "error", err)
+		return summary, fmt.Errorf("failed to summarize reviews for %s %d: %w", entityType, entityID, err)
+	}
+
+	summary.Histogram = make(map[int]int, len(stars))
+	for i, n := range stars {
+		summary.Histogram[i+1] = n
+	}
+	return summary, nil
+}
+
+// PlaceExists reports whether the reviewed place exists.
+func (r *ReviewRepository) PlaceExists(entityType string, entityID int) (bool, error) {
+	target, ok := reviewedTables[entityType]
+	if !ok {
+		return false, fmt.Errorf("unknown review entity type %q", entityType)
+	}
+
+	var exists bool
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE %s = $1)`, target.table, target.idColumn)
+	if err := r.db.QueryRow(query, entityID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to look up %s %d: %w", entityType, entityID, err)
+	}
+	return exists, nil
+}
+
+// Delete removes the user's review and refreshes the rating aggregates of the reviewed place.
 func (r *ReviewRepository) Delete(reviewID, userID int) error {
-	query := `DELETE FROM reviews WHERE review_id = $1 AND user_id = $2`
+	query := `DELETE FROM reviews WHERE review_id = $1 AND user_id = $2 RETURNING entity_type, entity_id`
 
-	res, err := r.db.Exec(query, reviewID, userID)
+	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to begin review transaction: %w", err)
 	}
-	rows, _ := res.RowsAffected()
-	if rows == 0 {
+	defer tx.Rollback()
+
+	var entityType string
+	var entityID int
+	err = tx.QueryRow(query, reviewID, userID).Scan(&entityType, &entityID)
+	if err == sql.ErrNoRows {
 		return fmt.Errorf("review not found or unauthorized")
 	}
+	if err != nil {
+		return err
+	}
+
+	if err := refreshRating(tx, entityType, entityID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetByID returns nil when the review does not exist.
+func (r *ReviewRepository) GetByID(reviewID int) (*models.Review, error) {
+	query := `
+		SELECT review_id, user_id, entity_type, entity_id, rating, comment, verified, status, flags,
+		       moderation_note, review_date, created_at, updated_at
+		FROM reviews WHERE review_id = $1`
+
+	var rev models.Review
+	err := r.db.QueryRow(query, reviewID).Scan(
+		&rev.ReviewID,
+		&rev.UserID,
+		&rev.EntityType,
+		&rev.EntityID,
+		&rev.Rating,
+		&rev.Comment,
+		&rev.Verified,
+		&rev.Status,
+		pq.Array(&rev.Flags),
+		&rev.ModerationNote,
+		&rev.ReviewDate,
+		&rev.CreatedAt,
+		&rev.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		slog.Error("Failed to fetch review", "review_id", reviewID, "error", err)
+		return nil, fmt.Errorf("failed to fetch review %d: %w", reviewID, err)
+	}
+	return &rev, nil
+}
+
+// Update saves the new rating, comment and flags of the author's review, archiving the
+// previous version in review_edits. review.Status is set to the status actually stored.
+func (r *ReviewRepository) Update(review *models.Review) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin review transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		INSERT INTO review_edits (review_id, rating, comment, edited_at)
+		SELECT review_id, rating, comment, NOW()
+		FROM reviews WHERE review_id = $1 AND user_id = $2`, review.ReviewID, review.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to archive review %d: %w", review.ReviewID, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrReviewNotFound
+	}
+
+	// review.Status is what the automatic checks allow. A rejected review goes back to the
+	// queue instead of being published by an edit; the moderator's decision and note stay.
+	query := `
+		UPDATE reviews
+		SET rating = $3, comment = $4, flags = $6, updated_at = NOW(),
+		    status = CASE WHEN status = 'rejected' AND $5::varchar = 'approved' THEN 'pending' ELSE $5::varchar END
+		WHERE review_id = $1 AND user_id = $2
+		RETURNING entity_type, entity_id, status, moderation_note, updated_at`
+	err = tx.QueryRow(query,
+		review.ReviewID, review.UserID, review.Rating, review.Comment, review.Status, pq.Array(review.Flags),
+	).Scan(&review.EntityType, &review.EntityID, &review.Status, &review.ModerationNote, &review.UpdatedAt)
+	if err != nil {
+		slog.Error("Failed to update review", "review_id", review.ReviewID, "error", err)
+		return fmt.Errorf("failed to update review %d: %w", review.ReviewID, err)
+	}
+
+	if err := refreshRating(tx, review.EntityType, review.EntityID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetHistory returns the previous versions of a review, most recent first.
+func (r *ReviewRepository) GetHistory(reviewID int) ([]models.ReviewEdit, error) {
+	query := `
+		SELECT edit_id, review_id, rating, comment, edited_at
+		FROM review_edits
+		WHERE review_id = $1
+		ORDER BY edited_at DESC, edit_id DESC`
+
+	rows, err := r.db.Query(query, reviewID)
+	if err != nil {
+		slog.Error("Failed to fetch review history", "review_id", reviewID, "error", err)
+		return nil, fmt.Errorf("failed to fetch history of review %d: %w", reviewID, err)
+	}
+	defer rows.Close()
+
+	edits := []models.ReviewEdit{}
+	for rows.Next() {
+		var e models.ReviewEdit
+		if err := rows.Scan(&e.EditID, &e.ReviewID, &e.Rating, &e.Comment, &e.EditedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review edit: %w", err)
+		}
+		edits = append(edits, e)
+	}
+	return edits, rows.Err()
+}
+
+var moderationList = listSpec[models.Review]{
+	idExpr: "rv.review_id",
+	id:     func(rv models.Review) int { return rv.ReviewID },
+	sorts: map[string]sortKey[models.Review]{
+		"created_at": {"rv.created_at", func(rv models.Review) interface{} { return rv.CreatedAt }},
+	},
+	aliases: map[string]string{
+		"oldest": "created_at",
+		"newest": "-created_at",
+	},
+	defaultSort: "oldest",
+}
+
+// GetByStatus pages through reviews in one moderation state, oldest first by default.
+func (r *ReviewRepository) GetByStatus(status string, params models.ListParams) (models.Page[models.Review], error) {
+	b := &whereBuilder{}
+	b.add("rv.status = ?", status)
+	plan, err := moderationList.plan(b, params)
+	if err != nil {
+		return models.Page[models.Review]{}, err
+	}
+
+	query := `
+		SELECT rv.review_id, rv.user_id, rv.entity_type, rv.entity_id, rv.rating, rv.comment, rv.verified,
+		       rv.status, rv.flags, rv.moderation_note, rv.review_date, rv.created_at, rv.updated_at,
+		       CASE
+		           WHEN rv.entity_type = 'hotel'      THEN COALESCE(h.name, 'Unknown Hotel')
+		           WHEN rv.entity_type = 'restaurant' THEN COALESCE(res.name, 'Unknown Restaurant')
+		           WHEN rv.entity_type = 'attraction' THEN COALESCE(a.name, 'Unknown Attraction')
+		           ELSE 'Unknown Entity'
+		       END AS entity_name,
+		       trim(u.first_name || ' ' || u.last_name)
+		FROM reviews rv
+		JOIN users u ON u.user_id = rv.user_id
+		LEFT JOIN hotels h        ON rv.entity_type = 'hotel'      AND rv.entity_id = h.hotel_id
+		LEFT JOIN restaurants res ON rv.entity_type = 'restaurant' AND rv.entity_id = res.restaurant_id
+		LEFT JOIN attractions a   ON rv.entity_type = 'attraction' AND rv.entity_id = a.attraction_id` +
+		b.clause() + plan.suffix()
+
+	rows, err := r.db.Query(query, b.args...)
+	if err != nil {
+		slog.Error("Failed to fetch moderation queue", "status", status, "error", err)
+		return models.Page[models.Review]{}, fmt.Errorf("failed to fetch %s reviews: %w", status, err)
+	}
+	defer rows.Close()
+
+	var reviews []models.Review
+	for rows.Next() {
+		var rev models.Review
+		if err := rows.Scan(
+			&rev.ReviewID,
+			&rev.UserID,
+			&rev.EntityType,
+			&rev.EntityID,
+			&rev.Rating,
+			&rev.Comment,
+			&rev.Verified,
+			&rev.Status,
+			pq.Array(&rev.Flags),
+			&rev.ModerationNote,
+			&rev.ReviewDate,
+			&rev.CreatedAt,
+			&rev.UpdatedAt,
+			&rev.EntityName,
+			&rev.ReviewerName,
+		); err != nil {
+			return models.Page[models.Review]{}, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Page[models.Review]{}, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return plan.page(reviews), nil
+}
+
+// SetStatus records a moderator's decision and refreshes the place's rating, since
+// only approved reviews count towards it.
+func (r *ReviewRepository) SetStatus(reviewID int, status string, moderatorID int, note string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin review transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE reviews
+		SET status = $2, moderated_by = $3, moderated_at = NOW(), moderation_note = $4
+		WHERE review_id = $1
+		RETURNING entity_type, entity_id`
+
+	var entityType string
+	var entityID int
+	err = tx.QueryRow(query, reviewID, status, moderatorID, note).Scan(&entityType, &entityID)
+	if err == sql.ErrNoRows {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		slog.Error("Failed to moderate review", "review_id", reviewID, "status", status, "error", err)
+		return fmt.Errorf("failed to set status of review %d: %w", reviewID, err)
+	}
+
+	if err := refreshRating(tx, entityType, entityID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// HasVisited reports whether the place appears in the itinerary of one of the user's
+// completed trips, the same rule GetVisitedHotels/Attractions/Restaurants apply.
+func (r *ReviewRepository) HasVisited(userID int, entityType string, entityID int) (bool, error) {
+	target, ok := reviewedTables[entityType]
+	if !ok {
+		return false, fmt.Errorf("unknown review entity type %q", entityType)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM itinerary_activities ia
+			JOIN trip_itinerary ti ON ia.itinerary_id = ti.itinerary_id
+			JOIN trips t ON ti.trip_id = t.trip_id
+			WHERE t.user_id = $1 AND t.status = 'Completed' AND ia.%s = $2
+		)`, target.idColumn)
+
+	var visited bool
+	if err := r.db.QueryRow(query, userID, entityID).Scan(&visited); err != nil {
+		slog.Error("Failed to check visited place", "user_id", userID, "entity_type", entityType, "entity_id", entityID, "error", err)
+		return false, fmt.Errorf("failed to check visit of %s %d: %w", entityType, entityID, err)
+	}
+	return visited, nil
+}
+
+// reviewedTables maps a review entity type to its table and primary key.
+var reviewedTables = map[string]struct{ table, idColumn string }{
+	"attraction": {"attractions", "attraction_id"},
+	"hotel":      {"hotels", "hotel_id"},
+	"restaurant": {"restaurants", "restaurant_id"},
+}
+
+// refreshRating recomputes review_count and review_average of one place from its approved
+// reviews; blended_rating follows from them as a generated column. The place row is locked
+// first and the aggregate runs as a separate statement, so it sees every review committed
+// by transactions that held the lock before, and concurrent reviews never lose a count.
+func refreshRating(tx *sql.Tx, entityType string, entityID int) error {
+	target, ok := reviewedTables[entityType]
+	if !ok {
+		return fmt.Errorf("unknown review entity type %q", entityType)
+	}
+
+	lock := fmt.Sprintf(`SELECT 1 FROM %s WHERE %s = $1 FOR UPDATE`, target.table, target.idColumn)
+	if _, err := tx.Exec(lock, entityID); err != nil {
+		slog.Error("Failed to lock reviewed place", "entity_type", entityType, "entity_id", entityID, "error", err)
+		return fmt.Errorf("failed to lock %s %d: %w", entityType, entityID, err)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET review_count = s.n, review_average = s.average
+		FROM (SELECT count(*) AS n, COALESCE(avg(rating), 0) AS average
+		      FROM reviews WHERE entity_type = $1 AND entity_id = $2 AND status = 'approved') s
+		WHERE %[2]s = $2`, target.table, target.idColumn)
+
+	if _, err := tx.Exec(query, entityType, entityID); err != nil {
+		slog.Error("Failed to refresh place rating", "entity_type", entityType, "entity_id", entityID, "error", err)
+		return fmt.Errorf("failed to refresh rating of %s %d: %w", entityType, entityID, err)
+	}
 	return nil
 }
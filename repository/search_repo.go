@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"travel-planning/models"
+
+	"github.com/lib/pq"
+)
+
+type SearchRepository struct {
+	db *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{
+		db: db,
+	}
+}
+
+// Documents mix the 'simple' config (names, kept verbatim) with 'english' (descriptions,
+// stemmed), so the query is parsed with both and either may match.
+const searchTerms = `
+	WITH q AS (
+		SELECT websearch_to_tsquery('simple', $1) || websearch_to_tsquery('english', $1) AS ts,
+		       lower($1) AS raw
+	)`
+
+// placeCandidates matches every place on its text document or, for typos and partial
+// names, on trigram word similarity of the name (cuisine too for restaurants).
+const placeCandidates = `
+	SELECT 'city' AS entity_type, c.city_id AS entity_id, c.name, co.name AS detail,
+	       c.city_id, c.name AS city_name, co.name AS country_name,
+	       c.latitude, c.longitude, 0::float8 AS rating,
+	       ts_rank(c.search_document, q.ts) AS text_rank,
+	       word_similarity(q.raw, c.name) AS name_similarity
+	FROM cities c
+	JOIN countries co ON co.country_id = c.country_id, q
+	WHERE c.search_document @@ q.ts OR q.raw <% c.name
+
+	UNION ALL
+	SELECT 'attraction', a.attraction_id, a.name, a.category,
+	       c.city_id, c.name, co.name,
+	       a.latitude, a.longitude, COALESCE(a.rating, 0),
+	       ts_rank(a.search_document, q.ts),
+	       word_similarity(q.raw, a.name)
+	FROM attractions a
+	JOIN cities c ON c.city_id = a.city_id
+	JOIN countries co ON co.country_id = c.country_id, q
+	WHERE a.search_document @@ q.ts OR q.raw <% a.name
+
+	UNION ALL
+	SELECT 'hotel', h.hotel_id, h.name,
+	       CASE WHEN h.stars > 0 THEN h.stars || '-star hotel' ELSE 'Hotel' END,
+	       c.city_id, c.name, co.name,
+	       h.latitude, h.longitude, COALESCE(h.rating, 0),
+	       ts_rank(h.search_document, q.ts),
+	       word_similarity(q.raw, h.name)
+	FROM hotels h
+	JOIN cities c ON c.city_id = h.city_id
+	JOIN countries co ON co.country_id = c.country_id, q
+	WHERE h.search_document @@ q.ts OR q.raw <% h.name
+
+	UNION ALL
+	SELECT 'restaurant', r.restaurant_id, r.name, r.cuisine,
+	       c.city_id, c.name, co.name,
+	       r.latitude, r.longitude, COALESCE(r.rating, 0),
+	       ts_rank(r.search_document, q.ts),
+	       GREATEST(word_similarity(q.raw, r.name), word_similarity(q.raw, r.cuisine))
+	FROM restaurants r
+	JOIN cities c ON c.city_id = r.city_id
+	JOIN countries co ON co.country_id = c.country_id, q
+	WHERE r.search_document @@ q.ts OR q.raw <% r.name OR q.raw <% r.cuisine`
+
+// haversineKm is the great-circle distance in km between the lat/lon columns and a point.
+func haversineKm(lat, lon, pointLat, pointLon string) string {
+	return fmt.Sprintf(`(2 * 6371 * asin(LEAST(1, sqrt(
+		power(sin(radians(%[1]s - %[3]s) / 2), 2) +
+		cos(radians(%[3]s)) * cos(radians(%[1]s)) * power(sin(radians(%[2]s - %[4]s) / 2), 2)))))`,
+		lat, lon, pointLat, pointLon)
+}
+
+// Search ranks matches by text rank plus name similarity. With a radius the score is
+// damped by distance so the closest of equally good matches come first.
+func (r *SearchRepository) Search(q models.SearchQuery) ([]models.SearchResult, error) {
+	distance := haversineKm("p.latitude", "p.longitude", "$4::float8", "$5::float8")
+	query := searchTerms + `,
+	places AS (` + placeCandidates + `
+	),
+	scored AS (
+		SELECT p.*, p.text_rank + p.name_similarity AS relevance,
+		       CASE WHEN $6::float8 > 0 AND p.latitude IS NOT NULL THEN ` + distance + ` END AS distance_km
+		FROM places p
+		WHERE p.entity_type = ANY($2) AND ($3 = 0 OR p.city_id = $3)
+	)
+	SELECT entity_type, entity_id, name, COALESCE(detail, ''), city_id, city_name, country_name,
+	       latitude, longitude, rating, distance_km,
+	       relevance / (1 + COALESCE(distance_km / NULLIF($6::float8, 0), 0)) AS score
+	FROM scored
+	WHERE $6::float8 = 0 OR distance_km <= $6::float8
+	ORDER BY score DESC, rating DESC, entity_id
+	LIMIT $7`
+
+	rows, err := r.db.Query(query, q.Text, pq.Array(q.Types), q.CityID, q.Latitude, q.Longitude, q.RadiusKm, q.Limit)
+	if err != nil {
+		slog.Error("Database error in place search", "query", q.Text, "error", err)
+		return nil, fmt.Errorf("failed to search places: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.SearchResult{}
+	for rows.Next() {
+		var (
+			res      models.SearchResult
+			lat, lon sql.NullFloat64
+			distance sql.NullFloat64
+		)
+		if err := rows.Scan(
+			&res.EntityType, &res.EntityID, &res.Name, &res.Detail, &res.CityID, &res.CityName, &res.CountryName,
+			&lat, &lon, &res.Rating, &distance, &res.Score,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if lat.Valid && lon.Valid {
+			res.Latitude, res.Longitude = &lat.Float64, &lon.Float64
+		}
+		if distance.Valid {
+			res.DistanceKm = &distance.Float64
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// ResolvePlace returns the city or attraction whose name best matches text, preferring
+// exact names and then cities. Nil when nothing is close enough.
+func (r *SearchRepository) ResolvePlace(text string) (*models.SearchResult, error) {
+	query := `
+		WITH q AS (SELECT lower($1) AS raw),
+		places AS (
+			SELECT 'city' AS entity_type, c.city_id AS entity_id, c.name, c.city_id, c.name AS city_name,
+			       co.name AS country_name, c.latitude, c.longitude, 0 AS priority
+			FROM cities c JOIN countries co ON co.country_id = c.country_id, q
+			WHERE q.raw <% c.name
+			UNION ALL
+			SELECT 'attraction', a.attraction_id, a.name, c.city_id, c.name, co.name, a.latitude, a.longitude, 1
+			FROM attractions a
+			JOIN cities c ON c.city_id = a.city_id
+			JOIN countries co ON co.country_id = c.country_id, q
+			WHERE q.raw <% a.name
+		)
+		SELECT p.entity_type, p.entity_id, p.name, p.city_id, p.city_name, p.country_name, p.latitude, p.longitude,
+		       word_similarity(q.raw, p.name) AS score
+		FROM places p, q
+		ORDER BY lower(p.name) = q.raw DESC, score DESC, p.priority, p.entity_id
+		LIMIT 1`
+
+	var (
+		res      models.SearchResult
+		lat, lon float64
+	)
+	err := r.db.QueryRow(query, text).Scan(
+		&res.EntityType, &res.EntityID, &res.Name, &res.CityID, &res.CityName, &res.CountryName,
+		&lat, &lon, &res.Score,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("Database error resolving place", "place", text, "error", err)
+		return nil, fmt.Errorf("failed to resolve place %q: %w", text, err)
+	}
+	res.Latitude, res.Longitude = &lat, &lon
+	return &res, nil
+}
@@ -52,7 +52,7 @@ func (r *TripItineraryRepository) Insert(tx *sql.Tx, itinerary *models.TripItine
 
 func (r *TripItineraryRepository) GetItineraryDaysByTripID(tripID int) ([]*models.TripItinerary, error) {
 	query := `
-        SELECT ti.itinerary_id, ti.trip_id, ti.day_number, ti.notes, ti.date, ti.created_at, t.status
+        SELECT ti.itinerary_id, ti.trip_id, ti.day_number, ti.notes, ti.date, ti.created_at, ti.route_distance_km, t.status
         FROM trip_itinerary ti
         JOIN trips t ON ti.trip_id = t.trip_id
         WHERE ti.trip_id = $1 
@@ -74,6 +74,7 @@ func (r *TripItineraryRepository) GetItineraryDaysByTripID(tripID int) ([]*model
 			&day.Notes,
 			&day.Date,
 			&day.CreatedAt,
+			&day.RouteKm,
 			&day.TripStatus,
 		)
 		if err != nil {
@@ -84,3 +85,42 @@ func (r *TripItineraryRepository) GetItineraryDaysByTripID(tripID int) ([]*model
 	}
 	return days, nil
 }
+
+func (r *TripItineraryRepository) UpdateRouteDistance(tx *sql.Tx, itineraryID int, distanceKm float64) error {
+	query := `UPDATE trip_itinerary SET route_distance_km = $1 WHERE itinerary_id = $2`
+
+	if _, err := tx.Exec(query, distanceKm, itineraryID); err != nil {
+		slog.Error("Failed to update route distance", "itinerary_id", itineraryID, "error", err)
+		return fmt.Errorf("failed to update route distance: %w", err)
+	}
+	return nil
+}
+
+// GetTripIDByItineraryID returns 0 when the itinerary day does not exist.
+func (r *TripItineraryRepository) GetTripIDByItineraryID(itineraryID int) (int, error) {
+	var tripID int
+	err := r.db.QueryRow(`SELECT trip_id FROM trip_itinerary WHERE itinerary_id = $1`, itineraryID).Scan(&tripID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to resolve trip of itinerary %d: %w", itineraryID, err)
+	}
+	return tripID, nil
+}
+
+// GetForUpdate locks an itinerary day for the rest of the transaction, so concurrent edits of
+// the same day are serialized. It returns nil when the day does not exist.
+func (r *TripItineraryRepository) GetForUpdate(tx *sql.Tx, itineraryID int64) (*models.TripItinerary, error) {
+	query := `SELECT itinerary_id, trip_id, day_number, date FROM trip_itinerary WHERE itinerary_id = $1 FOR UPDATE`
+
+	day := &models.TripItinerary{}
+	err := tx.QueryRow(query, itineraryID).Scan(&day.ItineraryID, &day.TripID, &day.DayNumber, &day.Date)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock itinerary day %d: %w", itineraryID, err)
+	}
+	return day, nil
+}
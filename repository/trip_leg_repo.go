@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"travel-planning/models"
+)
+
+type TripLegRepository struct {
+	db *sql.DB
+}
+
+func NewTripLegRepository(db *sql.DB) *TripLegRepository {
+	return &TripLegRepository{db: db}
+}
+
+func (r *TripLegRepository) Insert(tx *sql.Tx, leg *models.TripLeg) (int, error) {
+	query := `INSERT INTO trip_legs (trip_id, leg_order, city_id, arrival_date, departure_date)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING leg_id;`
+
+	var legID int
+	err := tx.QueryRow(
+		query,
+		leg.TripID,
+		leg.LegOrder,
+		leg.CityID,
+		leg.ArrivalDate,
+		leg.DepartureDate,
+	).Scan(&legID)
+
+	if err != nil {
+		slog.Error("Failed to insert trip leg",
+			"trip_id", leg.TripID,
+			"leg_order", leg.LegOrder,
+			"error", err,
+		)
+		return 0, fmt.Errorf("failed to insert trip leg: %w", err)
+	}
+
+	slog.Debug("Trip leg inserted", "leg_id", legID, "trip_id", leg.TripID, "city_id", leg.CityID)
+	return legID, nil
+}
+
+// GetByTripID returns the legs of a trip in travel order.
+func (r *TripLegRepository) GetByTripID(tripID int) ([]models.TripLeg, error) {
+	query := `
+        SELECT leg_id, trip_id, leg_order, city_id, arrival_date, departure_date
+        FROM trip_legs
+        WHERE trip_id = $1
+        ORDER BY leg_order ASC`
+
+	rows, err := r.db.Query(query, tripID)
+	if err != nil {
+		slog.Error("Failed to fetch trip legs", "trip_id", tripID, "error", err)
+		return nil, fmt.Errorf("failed to fetch legs for trip %d: %w", tripID, err)
+	}
+	defer rows.Close()
+
+	var legs []models.TripLeg
+	for rows.Next() {
+		var leg models.TripLeg
+		if err := rows.Scan(
+			&leg.LegID,
+			&leg.TripID,
+			&leg.LegOrder,
+			&leg.CityID,
+			&leg.ArrivalDate,
+			&leg.DepartureDate,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan trip leg: %w", err)
+		}
+		legs = append(legs, leg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return legs, nil
+}
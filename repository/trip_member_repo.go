@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"travel-planning/models"
+)
+
+type TripMemberRepository struct {
+	db *sql.DB
+}
+
+func NewTripMemberRepository(db *sql.DB) *TripMemberRepository {
+	return &TripMemberRepository{db: db}
+}
+
+// GetRole returns the user's role on the trip: owner for the creator, the stored role for
+// members and an empty string when the trip does not exist or the user has no access.
+func (r *TripMemberRepository) GetRole(tripID, userID int) (string, error) {
+	query := `
+        SELECT CASE WHEN t.user_id = $2 THEN 'owner' ELSE COALESCE(m.role, '') END
+        FROM trips t
+        LEFT JOIN trip_members m ON m.trip_id = t.trip_id AND m.user_id = $2
+        WHERE t.trip_id = $1`
+
+	var role string
+	err := r.db.QueryRow(query, tripID, userID).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		slog.Error("Failed to resolve trip role", "trip_id", tripID, "user_id", userID, "error", err)
+		return "", fmt.Errorf("failed to resolve trip role: %w", err)
+	}
+	return role, nil
+}
+
+// UpsertByEmail adds the registered user with this email to the trip, or changes their role.
+// It returns 0 when no user has the email. The owner cannot be added as a member.
+func (r *TripMemberRepository) UpsertByEmail(tripID int, email, role string, invitedBy int) (int, error) {
+	query := `
+        INSERT INTO trip_members (trip_id, user_id, role, invited_by, created_at)
+        SELECT $1, u.user_id, $3, $4, NOW()
+        FROM users u
+        JOIN trips t ON t.trip_id = $1
+        WHERE u.email = $2 AND u.user_id <> t.user_id
+        ON CONFLICT (trip_id, user_id) DO UPDATE SET role = EXCLUDED.role
+        RETURNING user_id`
+
+	var userID int
+	err := r.db.QueryRow(query, tripID, email, role, invitedBy).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		slog.Error("Failed to add trip member", "trip_id", tripID, "email", email, "error", err)
+		return 0, fmt.Errorf("failed to add trip member: %w", err)
+	}
+
+	slog.Info("Trip member saved", "trip_id", tripID, "user_id", userID, "role", role)
+	return userID, nil
+}
+
+// Delete removes a member. It reports false when the user was not a member.
+func (r *TripMemberRepository) Delete(tripID, userID int) (bool, error) {
+	res, err := r.db.Exec(`DELETE FROM trip_members WHERE trip_id = $1 AND user_id = $2`, tripID, userID)
+	if err != nil {
+		slog.Error("Failed to remove trip member", "trip_id", tripID, "user_id", userID, "error", err)
+		return false, fmt.Errorf("failed to remove trip member: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows > 0, nil
+}
+
+func (r *TripMemberRepository) GetByTripID(tripID int) ([]models.TripMember, error) {
+	query := `
+        SELECT m.trip_id, m.user_id, m.role, COALESCE(m.invited_by, 0), u.first_name, u.last_name, u.email, m.created_at
+        FROM trip_members m
+        JOIN users u ON m.user_id = u.user_id
+        WHERE m.trip_id = $1
+        ORDER BY m.created_at ASC`
+
+	rows, err := r.db.Query(query, tripID)
+	if err != nil {
+		slog.Error("Failed to fetch trip members", "trip_id", tripID, "error", err)
+		return nil, fmt.Errorf("failed to fetch members of trip %d: %w", tripID, err)
+	}
+	defer rows.Close()
+
+	var members []models.TripMember
+	for rows.Next() {
+		var m models.TripMember
+		if err := rows.Scan(
+			&m.TripID,
+			&m.UserID,
+			&m.Role,
+			&m.InvitedBy,
+			&m.FirstName,
+			&m.LastName,
+			&m.Email,
+			&m.CreatedAt,
+		); err != nil {
+			slog.Warn("Error scanning trip member row", "error", err)
+			continue
+		}
+		members = append(members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return members, nil
+}
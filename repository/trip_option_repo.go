@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+	"travel-planning/models"
+)
+
+type TripOptionRepository struct {
+	db *sql.DB
+}
+
+func NewTripOptionRepository(db *sql.DB) *TripOptionRepository {
+	return &TripOptionRepository{db: db}
+}
+
+// ReplaceForTrip drops the previously generated options of a trip and stores the new set,
+// filling in the generated option IDs.
+func (r *TripOptionRepository) ReplaceForTrip(tripID int, options []models.TripOption) ([]models.TripOption, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM trip_options WHERE trip_id = $1`, tripID); err != nil {
+		slog.Error("Failed to clear trip options", "trip_id", tripID, "error", err)
+		return nil, fmt.Errorf("failed to clear trip options: %w", err)
+	}
+
+	query := `INSERT INTO trip_options (
+        trip_id, tier, outbound_flight_id, inbound_flight_id, hotel_id,
+        logistics_budget, activities_budget, more_money, total_price, created_at,
+        rooms, flights_cost, hotel_cost, per_person_price, budget_profile
+    )
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+    RETURNING option_id;`
+
+	currTime := time.Now()
+	saved := make([]models.TripOption, 0, len(options))
+	for _, opt := range options {
+		if opt.OutBoundFlight == nil || opt.InBoundFlight == nil || opt.Hotel == nil {
+			return nil, fmt.Errorf("option %s is missing flights or hotel", opt.Tier)
+		}
+
+		opt.TripID = tripID
+		err := tx.QueryRow(
+			query,
+			tripID,
+			opt.Tier,
+			opt.OutBoundFlight.FlightID,
+			opt.InBoundFlight.FlightID,
+			opt.Hotel.HotelID,
+			opt.LogisticsBudget,
+			opt.ActivitiesBudget,
+			opt.MoreMoney,
+			opt.TotalPriceOfTrip,
+			currTime,
+			opt.Rooms,
+			opt.FlightsCost,
+			opt.HotelCost,
+			opt.PerPersonPrice,
+			opt.BudgetProfile,
+		).Scan(&opt.OptionID)
+		if err != nil {
+			slog.Error("Failed to insert trip option", "trip_id", tripID, "tier", opt.Tier, "error", err)
+			return nil, fmt.Errorf("failed to insert trip option %s: %w", opt.Tier, err)
+		}
+
+		for _, leg := range opt.Legs {
+			if leg.Hotel == nil || leg.ArrivalFlight == nil {
+				return nil, fmt.Errorf("option %s leg %d is missing flight or hotel", opt.Tier, leg.LegOrder)
+			}
+			_, err := tx.Exec(`INSERT INTO trip_option_legs (option_id, leg_order, city_id, nights, hotel_id, arrival_flight_id)
+            VALUES ($1, $2, $3, $4, $5, $6)`,
+				opt.OptionID, leg.LegOrder, leg.CityID, leg.Nights, leg.Hotel.HotelID, leg.ArrivalFlight.FlightID)
+			if err != nil {
+				slog.Error("Failed to insert trip option leg", "trip_id", tripID, "tier", opt.Tier, "leg_order", leg.LegOrder, "error", err)
+				return nil, fmt.Errorf("failed to insert leg %d of option %s: %w", leg.LegOrder, opt.Tier, err)
+			}
+		}
+		saved = append(saved, opt)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit trip options: %w", err)
+	}
+
+	slog.Debug("Trip options saved", "trip_id", tripID, "count", len(saved))
+	return saved, nil
+}
+
+const tripOptionSelect = `
+    SELECT
+        o.option_id, o.trip_id, o.tier, o.budget_profile,
+        o.logistics_budget, o.activities_budget, o.more_money, o.total_price,
+        t.adults, t.children, o.rooms, o.flights_cost, o.hotel_cost, o.per_person_price,
+        fo.flight_id, fo.from_city_id, fo.to_city_id, fo.airline, fo.duration_minutes, fo.price, COALESCE(fo.website, ''),
+        fi.flight_id, fi.from_city_id, fi.to_city_id, fi.airline, fi.duration_minutes, fi.price, COALESCE(fi.website, ''),
+        h.hotel_id, h.city_id, h.name, COALESCE(h.address, ''), h.stars, h.rating, h.price_per_night,
+        COALESCE(h.website, ''), COALESCE(h.description, ''), COALESCE(h.latitude, 0), COALESCE(h.longitude, 0)
+    FROM trip_options o
+    JOIN trips t    ON o.trip_id = t.trip_id
+    JOIN flights fo ON o.outbound_flight_id = fo.flight_id
+    JOIN flights fi ON o.inbound_flight_id = fi.flight_id
+    JOIN hotels h   ON o.hotel_id = h.hotel_id`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTripOption(row rowScanner) (*models.TripOption, error) {
+	opt := &models.TripOption{
+		OutBoundFlight: &models.Flight{},
+		InBoundFlight:  &models.Flight{},
+		Hotel:          &models.Hotel{},
+	}
+	out, in, h := opt.OutBoundFlight, opt.InBoundFlight, opt.Hotel
+
+	err := row.Scan(
+		&opt.OptionID, &opt.TripID, &opt.Tier, &opt.BudgetProfile,
+		&opt.LogisticsBudget, &opt.ActivitiesBudget, &opt.MoreMoney, &opt.TotalPriceOfTrip,
+		&opt.Adults, &opt.Children, &opt.Rooms, &opt.FlightsCost, &opt.HotelCost, &opt.PerPersonPrice,
+		&out.FlightID, &out.FromCityID, &out.ToCityID, &out.Airline, &out.DurationMinutes, &out.Price, &out.Website,
+		&in.FlightID, &in.FromCityID, &in.ToCityID, &in.Airline, &in.DurationMinutes, &in.Price, &in.Website,
+		&h.HotelID, &h.CityID, &h.Name, &h.Address, &h.Stars, &h.Rating, &h.PricePerNight,
+		&h.Website, &h.Description, &h.Latitude, &h.Longitude,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return opt, nil
+}
+
+func (r *TripOptionRepository) GetByTripID(tripID int) ([]models.TripOption, error) {
+	query := tripOptionSelect + `
+    WHERE o.trip_id = $1
+    ORDER BY o.total_price ASC`
+
+	rows, err := r.db.Query(query, tripID)
+	if err != nil {
+		slog.Error("Failed to fetch trip options", "trip_id", tripID, "error", err)
+		return nil, fmt.Errorf("failed to fetch trip options for trip %d: %w", tripID, err)
+	}
+	defer rows.Close()
+
+	var options []models.TripOption
+	for rows.Next() {
+		opt, err := scanTripOption(rows)
+		if err != nil {
+			slog.Warn("Error scanning trip option row", "trip_id", tripID, "error", err)
+			continue
+		}
+		options = append(options, *opt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	for i := range options {
+		if options[i].Legs, err = r.getLegs(options[i].OptionID); err != nil {
+			return nil, err
+		}
+	}
+	return options, nil
+}
+
+// GetByIDAndTripID returns nil when the option does not exist or belongs to another trip.
+func (r *TripOptionRepository) GetByIDAndTripID(optionID, tripID int) (*models.TripOption, error) {
+	query := tripOptionSelect + `
+    WHERE o.option_id = $1 AND o.trip_id = $2`
+
+	opt, err := scanTripOption(r.db.QueryRow(query, optionID, tripID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			slog.Debug("Trip option not found", "option_id", optionID, "trip_id", tripID)
+			return nil, nil
+		}
+		slog.Error("Database error fetching trip option", "option_id", optionID, "error", err)
+		return nil, fmt.Errorf("failed to fetch trip option %d: %w", optionID, err)
+	}
+
+	if opt.Legs, err = r.getLegs(opt.OptionID); err != nil {
+		return nil, err
+	}
+	return opt, nil
+}
+
+// getLegs loads the per-city hotel and arrival flight of an option in travel order.
+func (r *TripOptionRepository) getLegs(optionID int) ([]models.TripOptionLeg, error) {
+	query := `
+    SELECT
+        ol.leg_order, ol.city_id, ol.nights,
+        f.flight_id, f.from_city_id, f.to_city_id, f.airline, f.duration_minutes, f.price, COALESCE(f.website, ''),
+        h.hotel_id, h.city_id, h.name, COALESCE(h.address, ''), h.stars, h.rating, h.price_per_night,
+        COALESCE(h.website, ''), COALESCE(h.description, ''), COALESCE(h.latitude, 0), COALESCE(h.longitude, 0)
+    FROM trip_option_legs ol
+    JOIN flights f ON ol.arrival_flight_id = f.flight_id
+    JOIN hotels h  ON ol.hotel_id = h.hotel_id
+    WHERE ol.option_id = $1
+    ORDER BY ol.leg_order ASC`
+
+	rows, err := r.db.Query(query, optionID)
+	if err != nil {
+		slog.Error("Failed to fetch trip option legs", "option_id", optionID, "error", err)
+		return nil, fmt.Errorf("failed to fetch legs of option %d: %w", optionID, err)
+	}
+	defer rows.Close()
+
+	var legs []models.TripOptionLeg
+	for rows.Next() {
+		leg := models.TripOptionLeg{ArrivalFlight: &models.Flight{}, Hotel: &models.Hotel{}}
+		f, h := leg.ArrivalFlight, leg.Hotel
+		if err := rows.Scan(
+			&leg.LegOrder, &leg.CityID, &leg.Nights,
+			&f.FlightID, &f.FromCityID, &f.ToCityID, &f.Airline, &f.DurationMinutes, &f.Price, &f.Website,
+			&h.HotelID, &h.CityID, &h.Name, &h.Address, &h.Stars, &h.Rating, &h.PricePerNight,
+			&h.Website, &h.Description, &h.Latitude, &h.Longitude,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan trip option leg: %w", err)
+		}
+		legs = append(legs, leg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return legs, nil
+}
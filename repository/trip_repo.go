@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"time"
 	"travel-planning/models"
+
+	"github.com/lib/pq"
 )
 
 type TripRepository struct {
@@ -23,8 +25,8 @@ func (r *TripRepository) GetConn() *sql.DB {
 }
 
 func (r *TripRepository) Insert(tx *sql.Tx, trip *models.Trip) (int, error) {
-	query := `INSERT INTO trips (user_id, destination_city_id, title, start_date, end_date, duration, total_price, status, created_at, updated_at)
-    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9,$10)
+	query := `INSERT INTO trips (user_id, destination_city_id, title, start_date, end_date, duration, total_price, status, created_at, updated_at, planning_status, planning_updated_at, adults, children)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
     RETURNING trip_id;`
 
 	var tripID int
@@ -41,6 +43,10 @@ func (r *TripRepository) Insert(tx *sql.Tx, trip *models.Trip) (int, error) {
 		trip.Status,
 		currTime,
 		currTime,
+		models.PlanningQueued,
+		currTime,
+		trip.Adults,
+		trip.Children,
 	).Scan(&tripID)
 
 	if err != nil {
@@ -61,7 +67,8 @@ func (r *TripRepository) GetAllTripsByUserID(userID int) ([]models.Trip, error)
 
 	query := `SELECT 
                 trip_id, user_id, destination_city_id, title, start_date, end_date, 
-                duration, total_price, status, created_at, updated_at
+                duration, total_price, status, created_at, updated_at,
+                planning_status, planning_error, planning_updated_at, adults, children
               FROM trips 
               WHERE user_id = $1`
 
@@ -93,6 +100,11 @@ func (r *TripRepository) GetAllTripsByUserID(userID int) ([]models.Trip, error)
 			&status,
 			&t.CreatedAt,
 			&t.UpdatedAt,
+			&t.PlanningStatus,
+			&t.PlanningError,
+			&t.PlanningUpdatedAt,
+			&t.Adults,
+			&t.Children,
 		); err != nil {
 			slog.Warn("Error scanning trip row", "error", err)
 			continue
@@ -109,12 +121,75 @@ func (r *TripRepository) GetAllTripsByUserID(userID int) ([]models.Trip, error)
 	return trips, nil
 }
 
+// GetSharedTripsByUserID returns trips other users have invited this user to,
+// with AccessRole set to the user's role on each.
+func (r *TripRepository) GetSharedTripsByUserID(userID int) ([]models.Trip, error) {
+	query := `SELECT 
+                t.trip_id, t.user_id, t.destination_city_id, t.title, t.start_date, t.end_date, 
+                t.duration, t.total_price, t.status, t.created_at, t.updated_at,
+                t.planning_status, t.planning_error, t.planning_updated_at, t.adults, t.children,
+                m.role
+              FROM trips t
+              JOIN trip_members m ON m.trip_id = t.trip_id
+              WHERE m.user_id = $1`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		slog.Error("Failed to fetch shared trips for user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to fetch shared trips for user %d: %w", userID, err)
+	}
+	defer rows.Close()
+
+	var trips []models.Trip
+	for rows.Next() {
+		var t models.Trip
+		var startDate, endDate sql.NullTime
+		var totalPrice sql.NullFloat64
+		var duration sql.NullInt64
+		var status sql.NullString
+
+		if err := rows.Scan(
+			&t.TripID,
+			&t.UserID,
+			&t.DestinationCityID,
+			&t.Title,
+			&startDate,
+			&endDate,
+			&duration,
+			&totalPrice,
+			&status,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+			&t.PlanningStatus,
+			&t.PlanningError,
+			&t.PlanningUpdatedAt,
+			&t.Adults,
+			&t.Children,
+			&t.AccessRole,
+		); err != nil {
+			slog.Warn("Error scanning shared trip row", "error", err)
+			continue
+		}
+		t.StartDate = startDate.Time
+		t.EndDate = endDate.Time
+		t.Duration = int(duration.Int64)
+		t.TotalPrice = totalPrice.Float64
+		t.Status = status.String
+
+		trips = append(trips, t)
+	}
+
+	return trips, nil
+}
+
 func (r *TripRepository) GetTripByID(tripID int) (*models.Trip, error) {
 	slog.Debug("Fetching trip by ID", "trip_id", tripID)
 
 	query := `SELECT 
                 trip_id, user_id, destination_city_id, title, start_date, end_date, 
-                duration, total_price, status, created_at, updated_at
+                duration, total_price, status, created_at, updated_at,
+                planning_status, planning_error, planning_updated_at, adults, children, COALESCE(tier, ''),
+                COALESCE(activities_budget, 0)
               FROM trips 
               WHERE trip_id = $1`
 
@@ -136,6 +211,13 @@ func (r *TripRepository) GetTripByID(tripID int) (*models.Trip, error) {
 		&status,
 		&t.CreatedAt,
 		&t.UpdatedAt,
+		&t.PlanningStatus,
+		&t.PlanningError,
+		&t.PlanningUpdatedAt,
+		&t.Adults,
+		&t.Children,
+		&t.Tier,
+		&t.ActivitiesBudget,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -209,3 +291,38 @@ func (r *TripRepository) UpdateStatus(tripID, userID int, status string) error {
 	_, err := r.db.Exec(query, status, tripID, userID)
 	return err
 }
+
+// Confirm marks the trip as confirmed with the chosen tier and activities budget, unless it
+// already is confirmed or completed. The row stays locked for the rest of the transaction, so
+// a concurrent selection waits and then sees the trip confirmed. It reports false when nothing
+// changed.
+func (r *TripRepository) Confirm(tx *sql.Tx, tripID int, tier string, activitiesBudget float64) (bool, error) {
+	query := `UPDATE trips SET status = 'Confirmed', tier = $1, activities_budget = $2, updated_at = NOW()
+              WHERE trip_id = $3 AND status NOT IN ('Confirmed', 'Completed')`
+
+	res, err := tx.Exec(query, tier, activitiesBudget, tripID)
+	if err != nil {
+		slog.Error("Failed to confirm trip", "trip_id", tripID, "error", err)
+		return false, fmt.Errorf("failed to confirm trip: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows > 0, nil
+}
+
+// UpdatePlanningStatus moves the trip's planning job to status, but only from one of the
+// allowed previous states. It reports false when the transition was not applied.
+func (r *TripRepository) UpdatePlanningStatus(tripID int, status, reason string, allowedFrom []string) (bool, error) {
+	query := `UPDATE trips
+              SET planning_status = $1, planning_error = $2, planning_updated_at = NOW()
+              WHERE trip_id = $3 AND planning_status = ANY($4)`
+
+	res, err := r.db.Exec(query, status, reason, tripID, pq.Array(allowedFrom))
+	if err != nil {
+		slog.Error("Failed to update planning status", "trip_id", tripID, "status", status, "error", err)
+		return false, fmt.Errorf("failed to update planning status: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows > 0, nil
+}
@@ -61,15 +61,17 @@ func (r *UserPreferencesRepository) GetByUserID(userID int) (*models.UserPrefere
 	preferences := models.UserPreferences{}
 
 	query := `SELECT 
-                preference_id, user_id,  home_city_id, preferred_categories, created_at, updated_at
+                preference_id, user_id,  home_city_id, preferred_categories, budget_profile_id, created_at, updated_at
               FROM user_preferences 
               WHERE user_id = $1`
 
+	var budgetProfileID sql.NullInt64
 	err := r.db.QueryRow(query, userID).Scan(
 		&preferences.PreferenceID,
 		&preferences.UserID,
 		&preferences.HomeCityID,
 		&preferences.PreferredCategories,
+		&budgetProfileID,
 		&preferences.CreatedAt,
 		&preferences.UpdatedAt,
 	)
@@ -85,5 +87,24 @@ func (r *UserPreferencesRepository) GetByUserID(userID int) (*models.UserPrefere
 		)
 		return nil, fmt.Errorf("failed to fetch user preferences for user %d:%w", userID, err)
 	}
+	if budgetProfileID.Valid {
+		id := int(budgetProfileID.Int64)
+		preferences.BudgetProfileID = &id
+	}
 	return &preferences, nil
 }
+
+// SetBudgetProfile stores the user's chosen budget profile. It reports false when the
+// user has no preferences row yet.
+func (r *UserPreferencesRepository) SetBudgetProfile(userID, profileID int) (bool, error) {
+	query := `UPDATE user_preferences SET budget_profile_id = $1, updated_at = NOW() WHERE user_id = $2`
+
+	res, err := r.db.Exec(query, profileID, userID)
+	if err != nil {
+		slog.Error("Failed to set budget profile", "user_id", userID, "profile_id", profileID, "error", err)
+		return false, fmt.Errorf("failed to set budget profile: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows > 0, nil
+}
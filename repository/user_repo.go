@@ -23,7 +23,7 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	user := models.User{}
 
-	query := `SELECT user_id, first_name, last_name, email, password_hash, created_at
+	query := `SELECT user_id, first_name, last_name, email, password_hash, role, created_at
               FROM users WHERE email = $1`
 
 	err := r.db.QueryRow(query, email).Scan(
@@ -32,6 +32,7 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 		&user.LastName,
 		&user.Email,
 		&user.PasswordHash,
+		&user.Role,
 		&user.CreatedAt,
 	)
 
@@ -87,3 +88,50 @@ func (r *UserRepository) Insert(user *models.User, password string) (int, error)
 	slog.Info("New user registered successfully", "user_id", userID, "email", user.Email)
 	return userID, nil
 }
+
+// GetRole returns the user's role, or "" when the user does not exist.
+func (r *UserRepository) GetRole(userID int) (string, error) {
+	var role string
+	err := r.db.QueryRow(`SELECT role FROM users WHERE user_id = $1`, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		slog.Error("Database error fetching user role", "user_id", userID, "error", err)
+		return "", fmt.Errorf("error fetching role of user %d: %w", userID, err)
+	}
+	return role, nil
+}
+
+// HasAdmin reports whether any user holds the admin role.
+func (r *UserRepository) HasAdmin() (bool, error) {
+	var exists bool
+	if err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM users WHERE role = 'admin')`).Scan(&exists); err != nil {
+		slog.Error("Database error checking for admins", "error", err)
+		return false, fmt.Errorf("error checking for admins: %w", err)
+	}
+	return exists, nil
+}
+
+// SetRole changes the user's account role. It reports false when the user does not exist.
+func (r *UserRepository) SetRole(userID int, role string) (bool, error) {
+	res, err := r.db.Exec(`UPDATE users SET role = $2 WHERE user_id = $1`, userID, role)
+	if err != nil {
+		slog.Error("Database error setting user role", "user_id", userID, "role", role, "error", err)
+		return false, fmt.Errorf("error setting role of user %d: %w", userID, err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// SetRoleByEmail changes the role of the user registered with the email. It reports
+// false when no such user exists.
+func (r *UserRepository) SetRoleByEmail(email, role string) (bool, error) {
+	res, err := r.db.Exec(`UPDATE users SET role = $2 WHERE LOWER(email) = LOWER($1)`, email, role)
+	if err != nil {
+		slog.Error("Database error setting user role", "email", email, "role", role, "error", err)
+		return false, fmt.Errorf("error setting role of %s: %w", email, err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
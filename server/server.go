@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"travel-planning/handlers"
+	"travel-planning/models"
 	"travel-planning/services"
 
 	_ "travel-planning/docs"
@@ -22,6 +23,7 @@ type AppServer struct {
 	ReviewHandlers   *handlers.ReviewHandlers
 	UserHandlers     *handlers.UserHandlers
 	TripHandlers     *handlers.TripHandlers
+	AdminHandlers    *handlers.AdminHandlers
 	JWTService       *services.JWTService
 }
 
@@ -31,6 +33,7 @@ func NewAppServer(
 	reviewH *handlers.ReviewHandlers,
 	userH *handlers.UserHandlers,
 	tripH *handlers.TripHandlers,
+	adminH *handlers.AdminHandlers,
 	jwtS *services.JWTService,
 ) *AppServer {
 	return &AppServer{
@@ -39,6 +42,7 @@ func NewAppServer(
 		ReviewHandlers:   reviewH,
 		UserHandlers:     userH,
 		TripHandlers:     tripH,
+		AdminHandlers:    adminH,
 		JWTService:       jwtS,
 	}
 }
@@ -52,6 +56,9 @@ func (s *AppServer) Start(port string) {
 
 	r.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 	authMiddleware := s.JWTService.AuthMiddleware
+	// Admin-only routes are wrapped in requireRole(admin, ...), which checks the stored role.
+	requireRole := s.JWTService.RequireRole
+	admin := models.UserRoleAdmin
 
 	// Auth
 	r.HandleFunc("/login", s.AuthHandlers.LoginHandler).Methods("POST")
@@ -64,30 +71,76 @@ func (s *AppServer) Start(port string) {
 	r.HandleFunc("/api/hotels", authMiddleware(s.ResourceHandlers.GetAllHotelsHandler)).Methods("GET")
 	r.HandleFunc("/api/restaurants", authMiddleware(s.ResourceHandlers.GetAllRestaurantssHandler)).Methods("GET")
 	r.HandleFunc("/api/flights", authMiddleware(s.ResourceHandlers.GetAllFlightsHandler)).Methods("GET")
+	r.HandleFunc("/api/search", authMiddleware(s.ResourceHandlers.SearchPlacesHandler)).Methods("GET")
+	r.HandleFunc("/api/nearby", authMiddleware(s.ResourceHandlers.NearbyPlacesHandler)).Methods("GET")
 
 	// Reviews
 	r.HandleFunc("/api/reviews", authMiddleware(s.ReviewHandlers.GetUserReviewsHandler)).Methods("GET")
 	r.HandleFunc("/api/reviews", authMiddleware(s.ReviewHandlers.CreateReviewHandler)).Methods("POST")
 	r.HandleFunc("/api/reviews/{id}", authMiddleware(s.ReviewHandlers.DeleteReviewHandler)).Methods("DELETE")
+	r.HandleFunc("/api/reviews/{id}", authMiddleware(s.ReviewHandlers.UpdateReviewHandler)).Methods("PUT")
+	r.HandleFunc("/api/reviews/{id}/history", authMiddleware(s.ReviewHandlers.GetReviewHistoryHandler)).Methods("GET")
+	r.HandleFunc("/api/{collection:hotels|attractions|restaurants}/{id:[0-9]+}/reviews", authMiddleware(s.ReviewHandlers.GetPlaceReviewsHandler)).Methods("GET")
+
+	// Admin
+	r.HandleFunc("/api/admin/reviews", authMiddleware(requireRole(admin, s.ReviewHandlers.GetModerationQueueHandler))).Methods("GET")
+	r.HandleFunc("/api/admin/reviews/{id}/{decision:approve|reject}", authMiddleware(requireRole(admin, s.ReviewHandlers.ModerateReviewHandler))).Methods("POST")
+	r.HandleFunc("/api/admin/seed", authMiddleware(requireRole(admin, s.AdminHandlers.GetSeedJobsHandler))).Methods("GET")
+	r.HandleFunc("/api/admin/seed/{job}", authMiddleware(requireRole(admin, s.AdminHandlers.TriggerSeedJobHandler))).Methods("POST")
+	r.HandleFunc("/api/admin/attractions/{id}", authMiddleware(requireRole(admin, s.ResourceHandlers.UpdateAttractionHandler))).Methods("PUT")
+	r.HandleFunc("/api/admin/hotels/{id}", authMiddleware(requireRole(admin, s.ResourceHandlers.UpdateHotelHandler))).Methods("PUT")
+	r.HandleFunc("/api/admin/restaurants/{id}", authMiddleware(requireRole(admin, s.ResourceHandlers.UpdateRestaurantHandler))).Methods("PUT")
+	r.HandleFunc("/api/admin/users/{id}/role", authMiddleware(requireRole(admin, s.AdminHandlers.SetUserRoleHandler))).Methods("PUT")
 
 	// Trips
+	// Every route addressing a trip, itinerary day or activity by ID resolves the owning trip
+	// and checks the caller's role on it; other users' trips answer 404.
+	tripRole := s.TripHandlers.RequireTripRole
+	itineraryRole := s.TripHandlers.RequireItineraryRole
+	activityRole := s.TripHandlers.RequireActivityRole
+	owner, editor, viewer := models.TripRoleOwner, models.TripRoleEditor, models.TripRoleViewer
+
 	r.HandleFunc("/api/trips", authMiddleware(s.TripHandlers.GetUserTripsHandler)).Methods("GET")
-	r.HandleFunc("/api/trips/{id}", authMiddleware(s.TripHandlers.DeleteTripHandler)).Methods("DELETE")
-	r.HandleFunc("/api/trips/{id}/generate-options", authMiddleware(s.TripHandlers.GenerateTripOptions)).Methods("POST")
-	r.HandleFunc("/api/trips/{id}/select-option", authMiddleware(s.TripHandlers.SelectTripOption)).Methods("POST")
 	r.HandleFunc("/api/trips/create", authMiddleware(s.TripHandlers.CreateTripHandler)).Methods("POST")
+	r.HandleFunc("/api/trips/scoring-weights", authMiddleware(s.TripHandlers.GetScoringWeightsHandler)).Methods("GET")
+	r.HandleFunc("/api/trips/{id}", authMiddleware(tripRole(owner, s.TripHandlers.DeleteTripHandler))).Methods("DELETE")
+	r.HandleFunc("/api/trips/{id}/generate-options", authMiddleware(tripRole(editor, s.TripHandlers.GenerateTripOptions))).Methods("POST")
+	r.HandleFunc("/api/trips/{id}/events", s.JWTService.StreamAuthMiddleware(tripRole(viewer, s.TripHandlers.TripEventsHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/options", authMiddleware(tripRole(viewer, s.TripHandlers.GetTripOptionsHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/legs", authMiddleware(tripRole(viewer, s.TripHandlers.GetTripLegsHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/select-option", authMiddleware(tripRole(editor, s.TripHandlers.SelectTripOption))).Methods("POST")
+	r.HandleFunc("/api/trips/{id}/members", authMiddleware(tripRole(viewer, s.TripHandlers.GetTripMembersHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/members", authMiddleware(tripRole(owner, s.TripHandlers.InviteTripMemberHandler))).Methods("POST")
+	r.HandleFunc("/api/trips/{id}/members/{userId}", authMiddleware(tripRole(viewer, s.TripHandlers.RemoveTripMemberHandler))).Methods("DELETE")
 
 	r.HandleFunc("/api/users/me/visited", authMiddleware(s.ResourceHandlers.GetVisitedEntitiesHandler)).Methods("GET")
-	r.HandleFunc("/api/trips/{id}/complete", authMiddleware(s.TripHandlers.CompleteTripHandler)).Methods("POST", "OPTIONS")
+	r.HandleFunc("/api/trips/{id}/complete", authMiddleware(tripRole(owner, s.TripHandlers.CompleteTripHandler))).Methods("POST", "OPTIONS")
 
 	// Itinerary & Activities
-	r.HandleFunc("/api/trips/{id}/itinerary", authMiddleware(s.TripHandlers.GetTripItineraryHandler)).Methods("GET")
-	r.HandleFunc("/api/itineraries/{id}/activities", authMiddleware(s.TripHandlers.GetActivitiesHandler)).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/itinerary", authMiddleware(tripRole(viewer, s.TripHandlers.GetTripItineraryHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/itinerary.ics", authMiddleware(tripRole(viewer, s.TripHandlers.ExportItineraryICSHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/itinerary.pdf", authMiddleware(tripRole(viewer, s.TripHandlers.ExportItineraryPDFHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/itinerary.gpx", authMiddleware(tripRole(viewer, s.TripHandlers.ExportItineraryGPXHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/itinerary.kml", authMiddleware(tripRole(viewer, s.TripHandlers.ExportItineraryKMLHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/itinerary.geojson", authMiddleware(tripRole(viewer, s.TripHandlers.ExportItineraryGeoJSONHandler))).Methods("GET")
+	r.HandleFunc("/api/trips/{id}/days/{day}/regenerate", authMiddleware(tripRole(editor, s.TripHandlers.RegenerateDayHandler))).Methods("POST")
+	r.HandleFunc("/api/itineraries/{id}/activities", authMiddleware(itineraryRole(viewer, s.TripHandlers.GetActivitiesHandler))).Methods("GET")
+	r.HandleFunc("/api/itineraries/{id}/activities", authMiddleware(itineraryRole(editor, s.TripHandlers.AddActivityHandler))).Methods("POST")
+	r.HandleFunc("/api/activities/{id}", authMiddleware(activityRole(viewer, s.TripHandlers.GetActivityHandler))).Methods("GET")
+	r.HandleFunc("/api/activities/{id}", authMiddleware(activityRole(editor, s.TripHandlers.DeleteActivityHandler))).Methods("DELETE")
+	r.HandleFunc("/api/activities/{id}/move", authMiddleware(activityRole(editor, s.TripHandlers.MoveActivityHandler))).Methods("POST")
+	r.HandleFunc("/api/activities/{id}/alternatives", authMiddleware(activityRole(viewer, s.TripHandlers.GetActivityAlternativesHandler))).Methods("GET")
+	r.HandleFunc("/api/activities/{id}/replace", authMiddleware(activityRole(editor, s.TripHandlers.ReplaceActivityHandler))).Methods("POST")
 
 	// Users
 	r.HandleFunc("/api/users/register", s.UserHandlers.RegisterUserHandler).Methods("POST")
 	r.HandleFunc("/api/users/preferences", authMiddleware(s.UserHandlers.GetPreferencesHandler)).Methods("GET")
 	r.HandleFunc("/api/users/preferences", authMiddleware(s.UserHandlers.SetPreferencesHandler)).Methods("POST")
+	r.HandleFunc("/api/users/budget-profiles", authMiddleware(s.UserHandlers.GetBudgetProfilesHandler)).Methods("GET")
+	r.HandleFunc("/api/users/budget-profiles", authMiddleware(s.UserHandlers.CreateBudgetProfileHandler)).Methods("POST")
+	r.HandleFunc("/api/users/budget-profiles/{id}", authMiddleware(s.UserHandlers.UpdateBudgetProfileHandler)).Methods("PUT")
+	r.HandleFunc("/api/users/budget-profiles/{id}", authMiddleware(s.UserHandlers.DeleteBudgetProfileHandler)).Methods("DELETE")
+	r.HandleFunc("/api/users/budget-profiles/{id}/select", authMiddleware(s.UserHandlers.SelectBudgetProfileHandler)).Methods("POST")
 
 	slog.Info("Routes registered successfully")
 
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"travel-planning/models"
+)
+
+// AttractionScoringWeights controls how candidate attractions are ranked for a trip.
+// Every component is normalised to 0..1 before the weight is applied.
+type AttractionScoringWeights struct {
+	CategoryMatch float64 `json:"category_match"`
+	Rating        float64 `json:"rating"`
+	Fee           float64 `json:"fee"`
+	Distance      float64 `json:"distance"`
+}
+
+func DefaultAttractionScoringWeights() AttractionScoringWeights {
+	return AttractionScoringWeights{
+		CategoryMatch: 0.45,
+		Rating:        0.30,
+		Fee:           0.10,
+		Distance:      0.15,
+	}
+}
+
+// ForTier shifts the fee weight: Economy trips care more about cheap tickets,
+// Luxury trips not at all.
+func (w AttractionScoringWeights) ForTier(tier string) AttractionScoringWeights {
+	switch tier {
+	case "Economy":
+		w.Fee *= 2
+	case "Luxury":
+		w.Fee = 0
+	}
+	return w
+}
+
+type ScoredAttraction struct {
+	models.Attraction
+	Score             float64 `json:"score"`
+	MatchesPreference bool    `json:"matches_preference"`
+}
+
+// ParsePreferredCategories splits the comma-separated preference string stored on the user.
+func ParsePreferredCategories(raw string) []string {
+	var categories []string
+	for _, c := range strings.Split(raw, ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c != "" {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+// ScoreAttractions ranks attractions best first. Fee is scored against feeLimit and
+// distance against the farthest candidate from base.
+func ScoreAttractions(attractions []models.Attraction, preferred []string, base GeoPoint, feeLimit float64, w AttractionScoringWeights) []ScoredAttraction {
+	preferredSet := make(map[string]bool, len(preferred))
+	for _, c := range preferred {
+		preferredSet[c] = true
+	}
+
+	distances := make([]float64, len(attractions))
+	maxDistance := 0.0
+	for i, a := range attractions {
+		distances[i] = calculateDistance(base.Latitude, base.Longitude, a.Latitude, a.Longitude)
+		if distances[i] > maxDistance {
+			maxDistance = distances[i]
+		}
+	}
+
+	scored := make([]ScoredAttraction, len(attractions))
+	for i, a := range attractions {
+		match := preferredSet[strings.ToLower(a.Category)]
+
+		score := w.Rating * clamp01(a.BlendedRating/5)
+		if match {
+			score += w.CategoryMatch
+		}
+		if feeLimit > 0 {
+			score += w.Fee * (1 - clamp01(a.EntryFee/feeLimit))
+		} else if a.EntryFee == 0 {
+			score += w.Fee
+		}
+		if maxDistance > 0 {
+			score += w.Distance * (1 - distances[i]/maxDistance)
+		} else {
+			score += w.Distance
+		}
+
+		scored[i] = ScoredAttraction{Attraction: a, Score: score, MatchesPreference: match}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+	return scored
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"travel-planning/models"
+)
+
+func TestParsePreferredCategories(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"", nil},
+		{" , ,", nil},
+		{"museum", []string{"museum"}},
+		{" Museum, GALLERY ,,viewpoint ", []string{"museum", "gallery", "viewpoint"}},
+	}
+	for _, tt := range tests {
+		if got := ParsePreferredCategories(tt.raw); !slices.Equal(got, tt.want) {
+			t.Errorf("ParsePreferredCategories(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestAttractionScoringWeightsForTier(t *testing.T) {
+	base := DefaultAttractionScoringWeights()
+	tests := []struct {
+		tier string
+		fee  float64
+	}{
+		{"Economy", base.Fee * 2},
+		{"Balanced", base.Fee},
+		{"Luxury", 0},
+	}
+	for _, tt := range tests {
+		got := base.ForTier(tt.tier)
+		if got.Fee != tt.fee {
+			t.Errorf("ForTier(%q).Fee = %v, want %v", tt.tier, got.Fee, tt.fee)
+		}
+		if got.CategoryMatch != base.CategoryMatch || got.Rating != base.Rating || got.Distance != base.Distance {
+			t.Errorf("ForTier(%q) changed weights other than the fee: %+v", tt.tier, got)
+		}
+	}
+}
+
+func TestScoreAttractions(t *testing.T) {
+	scored := func(id int, category string, rating, fee, lon float64) models.Attraction {
+		return models.Attraction{AttractionID: id, Category: category, BlendedRating: rating, EntryFee: fee, Longitude: lon}
+	}
+
+	tests := []struct {
+		name        string
+		attractions []models.Attraction
+		preferred   []string
+		feeLimit    float64
+		weights     AttractionScoringWeights
+		wantOrder   []int
+		wantScores  []float64
+	}{
+		{
+			"category match ignores case",
+			[]models.Attraction{scored(1, "Park", 0, 0, 0), scored(2, "Museum", 0, 0, 0)},
+			[]string{"museum"}, 0,
+			AttractionScoringWeights{CategoryMatch: 1},
+			[]int{2, 1}, []float64{1, 0},
+		},
+		{
+			"rating is scaled to five stars and capped",
+			[]models.Attraction{scored(1, "", 5, 0, 0), scored(2, "", 2.5, 0, 0), scored(3, "", 7, 0, 0)},
+			nil, 0,
+			AttractionScoringWeights{Rating: 1},
+			[]int{1, 3, 2}, []float64{1, 1, 0.5},
+		},
+		{
+			"fee is scored against the limit",
+			[]models.Attraction{scored(1, "", 0, 40, 0), scored(2, "", 0, 10, 0), scored(3, "", 0, 0, 0)},
+			nil, 20,
+			AttractionScoringWeights{Fee: 1},
+			[]int{3, 2, 1}, []float64{1, 0.5, 0},
+		},
+		{
+			"without a fee limit only free entry scores",
+			[]models.Attraction{scored(1, "", 0, 5, 0), scored(2, "", 0, 0, 0)},
+			nil, 0,
+			AttractionScoringWeights{Fee: 1},
+			[]int{2, 1}, []float64{1, 0},
+		},
+		{
+			"distance is scored against the farthest candidate",
+			[]models.Attraction{scored(1, "", 0, 0, 1), scored(2, "", 0, 0, 0.5), scored(3, "", 0, 0, 0)},
+			nil, 0,
+			AttractionScoringWeights{Distance: 1},
+			[]int{3, 2, 1}, []float64{1, 0.5, 0},
+		},
+		{
+			"candidates at the base all score full distance",
+			[]models.Attraction{scored(1, "", 0, 0, 0), scored(2, "", 0, 0, 0)},
+			nil, 0,
+			AttractionScoringWeights{Distance: 1},
+			[]int{1, 2}, []float64{1, 1},
+		},
+		{
+			"default weights add up to one",
+			[]models.Attraction{scored(1, "museum", 5, 0, 0), scored(2, "park", 0, 20, 0)},
+			[]string{"museum"}, 20,
+			DefaultAttractionScoringWeights(),
+			[]int{1, 2}, []float64{1, 0.15},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScoreAttractions(tt.attractions, tt.preferred, GeoPoint{}, tt.feeLimit, tt.weights)
+			var order []int
+			for i, s := range got {
+				order = append(order, s.AttractionID)
+				if math.Abs(s.Score-tt.wantScores[i]) > 1e-9 {
+					t.Errorf("attraction %d score = %v, want %v", s.AttractionID, s.Score, tt.wantScores[i])
+				}
+				wantMatch := slices.Contains(tt.preferred, strings.ToLower(s.Category))
+				if s.MatchesPreference != wantMatch {
+					t.Errorf("attraction %d MatchesPreference = %v, want %v", s.AttractionID, s.MatchesPreference, wantMatch)
+				}
+			}
+			if !slices.Equal(order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+		})
+	}
+}
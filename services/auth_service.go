@@ -36,7 +36,7 @@ func (h *AuthService) Login(email, password string) (string, string, error) {
 		return "", "", fmt.Errorf("invalid credentials")
 	}
 
-	token, err := h.JWTService.GenerateToken(user.UserID)
+	token, err := h.JWTService.GenerateToken(user.UserID, user.Role)
 	if err != nil {
 		l.Error("Failed to generate access token", "user_id", user.UserID, "error", err)
 		return "", "", err
@@ -60,7 +60,18 @@ func (h *AuthService) RefreshToken(refreshToken string) (string, error) {
 		return "", err
 	}
 
-	newAccessToken, err := h.JWTService.GenerateToken(claims.UserID)
+	// Roles are read again so promotions and demotions apply from the next refresh.
+	role, err := h.UserRepo.GetRole(claims.UserID)
+	if err != nil {
+		slog.Error("Failed to look up role during refresh", "user_id", claims.UserID, "error", err)
+		return "", err
+	}
+	if role == "" {
+		slog.Warn("Token refresh failed: user no longer exists", "user_id", claims.UserID)
+		return "", fmt.Errorf("invalid refresh token")
+	}
+
+	newAccessToken, err := h.JWTService.GenerateToken(claims.UserID, role)
 	if err != nil {
 		slog.Error("Failed to generate new access token during refresh", "user_id", claims.UserID, "error", err)
 		return "", nil
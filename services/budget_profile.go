@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"travel-planning/models"
+)
+
+var ErrInvalidBudgetProfile = errors.New("invalid budget profile")
+
+var ErrBudgetProfileNotFound = errors.New("budget profile not found")
+
+// DefaultBudgetProfile is the split used when neither the user nor the database has a profile.
+func DefaultBudgetProfile() models.BudgetProfile {
+	return models.BudgetProfile{
+		Name:            models.DefaultBudgetProfileName,
+		LogisticsShare:  50,
+		ActivitiesShare: 30,
+		ExtraShare:      20,
+		FlightShare:     60,
+		BuiltIn:         true,
+	}
+}
+
+// ValidateBudgetProfile checks that the three budget shares add up to 100% and that some
+// money is left for both flights and hotels.
+func ValidateBudgetProfile(p models.BudgetProfile) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidBudgetProfile)
+	}
+	for _, share := range []float64{p.LogisticsShare, p.ActivitiesShare, p.ExtraShare} {
+		if share < 0 || share > 100 {
+			return fmt.Errorf("%w: shares must be between 0 and 100", ErrInvalidBudgetProfile)
+		}
+	}
+	if p.LogisticsShare == 0 {
+		return fmt.Errorf("%w: logistics share must be positive", ErrInvalidBudgetProfile)
+	}
+	if sum := p.LogisticsShare + p.ActivitiesShare + p.ExtraShare; math.Abs(sum-100) > 0.01 {
+		return fmt.Errorf("%w: shares add up to %.2f%%, expected 100%%", ErrInvalidBudgetProfile, sum)
+	}
+	if p.FlightShare <= 0 || p.FlightShare >= 100 {
+		return fmt.Errorf("%w: flight share must be between 0 and 100, exclusive", ErrInvalidBudgetProfile)
+	}
+	return nil
+}
+
+// BudgetSplit is a trip budget divided according to a profile.
+type BudgetSplit struct {
+	Logistics  float64
+	Flights    float64
+	Activities float64
+	Extra      float64
+}
+
+func SplitBudget(total float64, p models.BudgetProfile) BudgetSplit {
+	logistics := total * p.LogisticsShare / 100
+	return BudgetSplit{
+		Logistics:  logistics,
+		Flights:    logistics * p.FlightShare / 100,
+		Activities: total * p.ActivitiesShare / 100,
+		Extra:      total * p.ExtraShare / 100,
+	}
+}
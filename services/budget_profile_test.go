@@ -0,0 +1,72 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"travel-planning/models"
+)
+
+func TestValidateBudgetProfile(t *testing.T) {
+	profile := func(name string, logistics, activities, extra, flights float64) models.BudgetProfile {
+		return models.BudgetProfile{
+			Name:            name,
+			LogisticsShare:  logistics,
+			ActivitiesShare: activities,
+			ExtraShare:      extra,
+			FlightShare:     flights,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		profile models.BudgetProfile
+		valid   bool
+	}{
+		{"default", DefaultBudgetProfile(), true},
+		{"backpacker", profile("backpacker", 70, 25, 5, 50), true},
+		{"rounding tolerance", profile("thirds", 33.33, 33.33, 33.34, 50), true},
+		{"no activities or extra", profile("logistics only", 100, 0, 0, 40), true},
+		{"missing name", profile("  ", 50, 30, 20, 60), false},
+		{"sum below 100", profile("short", 50, 30, 10, 60), false},
+		{"sum above 100", profile("over", 60, 30, 20, 60), false},
+		{"negative share", profile("negative", 110, -10, 0, 60), false},
+		{"share above 100", profile("huge", 150, 0, -50, 60), false},
+		{"no logistics", profile("free travel", 0, 60, 40, 60), false},
+		{"no flights", profile("no flights", 50, 30, 20, 0), false},
+		{"no hotels", profile("no hotels", 50, 30, 20, 100), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBudgetProfile(tt.profile)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateBudgetProfile = %v, want nil", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidBudgetProfile) {
+				t.Errorf("ValidateBudgetProfile = %v, want ErrInvalidBudgetProfile", err)
+			}
+		})
+	}
+}
+
+func TestSplitBudget(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   float64
+		profile models.BudgetProfile
+		want    BudgetSplit
+	}{
+		{"default", 1000, DefaultBudgetProfile(), BudgetSplit{Logistics: 500, Flights: 300, Activities: 300, Extra: 200}},
+		{
+			"backpacker",
+			2000,
+			models.BudgetProfile{LogisticsShare: 70, ActivitiesShare: 25, ExtraShare: 5, FlightShare: 50},
+			BudgetSplit{Logistics: 1400, Flights: 700, Activities: 500, Extra: 100},
+		},
+		{"zero budget", 0, DefaultBudgetProfile(), BudgetSplit{}},
+	}
+	for _, tt := range tests {
+		if got := SplitBudget(tt.total, tt.profile); got != tt.want {
+			t.Errorf("%s: SplitBudget = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"travel-planning/models"
+	"travel-planning/repository"
+)
+
+var (
+	ErrCatalogEntryNotFound = errors.New("catalog entry not found")
+	ErrInvalidCatalogEntry  = errors.New("invalid catalog entry")
+	ErrCatalogConflict      = repository.ErrCatalogConflict
+)
+
+var validPriceRanges = map[string]bool{"": true, "$": true, "$$": true, "$$$": true}
+
+// validatePlace checks the fields every catalog place shares.
+func validatePlace(name string, rating, lat, lon float64) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidCatalogEntry)
+	case rating < 0 || rating > 5:
+		return fmt.Errorf("%w: rating must be between 0 and 5", ErrInvalidCatalogEntry)
+	case lat < -90 || lat > 90 || lon < -180 || lon > 180:
+		return fmt.Errorf("%w: coordinates out of range", ErrInvalidCatalogEntry)
+	}
+	return nil
+}
+
+// UpdateAttraction overwrites an attraction's details. The city cannot be changed.
+func (s *ResourceService) UpdateAttraction(attractionID int, a models.Attraction) (*models.Attraction, error) {
+	if err := validatePlace(a.Name, a.Rating, a.Latitude, a.Longitude); err != nil {
+		return nil, err
+	}
+	if a.EntryFee < 0 {
+		return nil, fmt.Errorf("%w: entry_fee must not be negative", ErrInvalidCatalogEntry)
+	}
+
+	a.AttractionID = attractionID
+	a.Name = strings.TrimSpace(a.Name)
+	found, err := s.AttractionRepo.Update(&a)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrCatalogEntryNotFound
+	}
+	slog.Info("Attraction updated", "attraction_id", attractionID)
+	return s.AttractionRepo.GetByID(attractionID)
+}
+
+// UpdateHotel overwrites a hotel's details. The city cannot be changed.
+func (s *ResourceService) UpdateHotel(hotelID int, h models.Hotel) (*models.Hotel, error) {
+	if err := validatePlace(h.Name, h.Rating, h.Latitude, h.Longitude); err != nil {
+		return nil, err
+	}
+	if h.Stars < 0 || h.Stars > 5 {
+		return nil, fmt.Errorf("%w: stars must be between 0 and 5", ErrInvalidCatalogEntry)
+	}
+	if h.PricePerNight < 0 {
+		return nil, fmt.Errorf("%w: price_per_night must not be negative", ErrInvalidCatalogEntry)
+	}
+
+	h.HotelID = hotelID
+	h.Name = strings.TrimSpace(h.Name)
+	found, err := s.HotelRepo.Update(&h)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrCatalogEntryNotFound
+	}
+	slog.Info("Hotel updated", "hotel_id", hotelID)
+	return s.HotelRepo.GetHotelByID(hotelID)
+}
+
+// UpdateRestaurant overwrites a restaurant's details. The city cannot be changed.
+func (s *ResourceService) UpdateRestaurant(restaurantID int, r models.Restaurant) (*models.Restaurant, error) {
+	if err := validatePlace(r.Name, r.Rating, r.Latitude, r.Longitude); err != nil {
+		return nil, err
+	}
+	if !validPriceRanges[r.PriceRange] {
+		return nil, fmt.Errorf("%w: price_range must be $, $$ or $$$", ErrInvalidCatalogEntry)
+	}
+
+	r.RestaurantID = restaurantID
+	r.Name = strings.TrimSpace(r.Name)
+	found, err := s.RestaurantRepo.Update(&r)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrCatalogEntryNotFound
+	}
+	slog.Info("Restaurant updated", "restaurant_id", restaurantID)
+	return s.RestaurantRepo.GetByID(restaurantID)
+}
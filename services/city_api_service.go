@@ -112,6 +112,7 @@ func (s *CityAPIService) FetchCitiesByCountry(countryCode string) ([]*models.Cit
 			Latitude:    element.Lat,
 			Longitude:   element.Lon,
 			Description: fmt.Sprintf("Top city in %s (Pop: %d)", countryCode, population),
+			Timezone:    element.Tags["timezone"],
 		}
 		cities = append(cities, newCity)
 	}
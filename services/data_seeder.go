@@ -98,6 +98,7 @@ func (s *DataSeeder) SeedCities() error {
 				Latitude:    cityData.Latitude,
 				Longitude:   cityData.Longitude,
 				Description: cityData.Description,
+				Timezone:    cityData.Timezone,
 			}
 
 			if _, err := s.cityRepo.Upsert(newCity); err != nil {
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"travel-planning/models"
+)
+
+var ErrDayNotRegenerable = errors.New("itinerary day cannot be regenerated")
+
+// defaultRegenerationTier is used for trips confirmed before the selected tier was stored.
+const defaultRegenerationTier = "Balanced"
+
+// RegenerateDay reruns the attraction and restaurant selection for one sightseeing day of a
+// confirmed trip and swaps the day's activities in a single transaction. Attractions used on
+// other days are never picked; the day's current attractions are only reused when nothing
+// else is left in the city.
+func (s *TripPlanningService) RegenerateDay(tripID, dayNumber int) ([]*models.ItineraryActivity, error) {
+	l := slog.With("trip_id", tripID, "day", dayNumber)
+
+	trip, err := s.TripRepo.GetTripByID(tripID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.Status != "Confirmed" {
+		return nil, fmt.Errorf("%w: only confirmed trips have a generated itinerary", ErrDayNotRegenerable)
+	}
+
+	days, err := s.ItineraryRepo.GetItineraryDaysByTripID(tripID)
+	if err != nil {
+		return nil, err
+	}
+	var day *models.TripItinerary
+	for _, d := range days {
+		if d.DayNumber == dayNumber {
+			day = d
+			break
+		}
+	}
+	if day == nil {
+		return nil, fmt.Errorf("%w: trip has no day %d", ErrDayNotRegenerable, dayNumber)
+	}
+
+	legs, err := s.tripLegs(trip)
+	if err != nil {
+		return nil, fmt.Errorf("could not load trip legs: %w", err)
+	}
+	li := legIndexFor(legs, day.Date)
+	if day.ItineraryID == days[0].ItineraryID || day.ItineraryID == days[len(days)-1].ItineraryID ||
+		sameDate(day.Date, legs[li].ArrivalDate) {
+		return nil, fmt.Errorf("%w: day %d is a travel day", ErrDayNotRegenerable, dayNumber)
+	}
+
+	optionLeg, err := s.legLogistics(days, legs, li)
+	if err != nil {
+		return nil, err
+	}
+
+	usedElsewhere, err := s.ItineraryActivitiesRepo.GetAttractionIDsByTripID(tripID, int64(day.ItineraryID))
+	if err != nil {
+		return nil, err
+	}
+	exclude := make(map[int]bool, len(usedElsewhere))
+	for _, id := range usedElsewhere {
+		exclude[id] = true
+	}
+	current, err := s.ItineraryActivitiesRepo.GetActivitiesByItineraryID(day.ItineraryID)
+	if err != nil {
+		return nil, err
+	}
+	withCurrent := make(map[int]bool, len(exclude)+len(current))
+	for id := range exclude {
+		withCurrent[id] = true
+	}
+	for _, a := range current {
+		if a.AttractionID.Valid {
+			withCurrent[int(a.AttractionID.Int64)] = true
+		}
+	}
+
+	tier := trip.Tier
+	if tier == "" {
+		tier = defaultRegenerationTier
+	}
+	feeLimit := attractionFeeLimit(trip, s.finalizedActivitiesBudget(trip), len(days))
+	preferred := s.preferredCategories(trip.UserID)
+	weights := s.ScoringWeights.ForTier(tier)
+
+	var plan *legPlan
+	for _, skip := range []map[int]bool{withCurrent, exclude} {
+		plan, err = s.planLeg(legs[li], optionLeg, tier, preferred, weights, feeLimit, 1, skip)
+		if err == nil && len(plan.routes) > 0 && len(plan.routes[0].Attractions) > 0 {
+			break
+		}
+		plan = nil
+	}
+	if plan == nil {
+		return nil, fmt.Errorf("%w: no unused attractions left in this city", ErrDayNotRegenerable)
+	}
+
+	schedule := s.Scheduler.Schedule(day.Date, buildDayStops(plan.routes[0], plan.restaurants, plan.hotelStop, plan.matched))
+
+	tx, err := s.TripRepo.GetConn().Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	itineraryID := int64(day.ItineraryID)
+	if _, err := s.ItineraryRepo.GetForUpdate(tx, itineraryID); err != nil {
+		return nil, err
+	}
+	if err := s.ItineraryActivitiesRepo.DeleteByItineraryID(tx, itineraryID); err != nil {
+		return nil, err
+	}
+	for order, slot := range schedule {
+		if err := s.saveActivity(tx, itineraryID, slot, order, plan.attractions); err != nil {
+			return nil, err
+		}
+	}
+	km := scheduledDistance(plan.base, schedule)
+	if err := s.ItineraryRepo.UpdateRouteDistance(tx, day.ItineraryID, km); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit regenerated day: %w", err)
+	}
+
+	l.Info("Itinerary day regenerated", "itinerary_id", itineraryID, "tier", tier, "stops", len(schedule), "distance_km", roundKm(km))
+	return s.ItineraryActivitiesRepo.GetActivitiesByItineraryID(day.ItineraryID)
+}
+
+// legLogistics recovers the hotel and arrival flight of a leg from the activities saved on its days.
+func (s *TripPlanningService) legLogistics(days []*models.TripItinerary, legs []models.TripLeg, li int) (models.TripOptionLeg, error) {
+	optionLeg := models.TripOptionLeg{
+		LegOrder:      legs[li].LegOrder,
+		CityID:        legs[li].CityID,
+		Nights:        legs[li].Nights(),
+		ArrivalFlight: &models.Flight{},
+	}
+
+	for _, d := range days {
+		if legIndexFor(legs, d.Date) != li {
+			continue
+		}
+		activities, err := s.ItineraryActivitiesRepo.GetActivitiesByItineraryID(d.ItineraryID)
+		if err != nil {
+			return optionLeg, err
+		}
+		for _, a := range activities {
+			if a.HotelID.Valid && optionLeg.Hotel == nil {
+				optionLeg.Hotel = &models.Hotel{HotelID: int(a.HotelID.Int64)}
+			}
+			if a.FlightID.Valid && optionLeg.ArrivalFlight.FlightID == 0 && sameDate(d.Date, legs[li].ArrivalDate) {
+				optionLeg.ArrivalFlight.FlightID = int(a.FlightID.Int64)
+			}
+		}
+	}
+
+	if optionLeg.Hotel == nil {
+		return optionLeg, fmt.Errorf("%w: no hotel found for the stay in city %d", ErrDayNotRegenerable, legs[li].CityID)
+	}
+	return optionLeg, nil
+}
+
+// finalizedActivitiesBudget is the activities budget the trip was confirmed with, so a
+// regenerated day is held to the same fee limit as the rest of the itinerary even after the
+// user changes budget profiles or generates new options. Trips confirmed before the budget
+// was stored fall back to the current profile, as finalizeOption does.
+func (s *TripPlanningService) finalizedActivitiesBudget(trip *models.Trip) float64 {
+	if trip.ActivitiesBudget > 0 {
+		return trip.ActivitiesBudget
+	}
+	return SplitBudget(trip.TotalPrice, s.budgetProfile(trip.UserID)).Activities
+}
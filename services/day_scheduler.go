@@ -0,0 +1,208 @@
+package services
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+	"travel-planning/models"
+)
+
+const (
+	MealLunch  = "lunch"
+	MealDinner = "dinner"
+)
+
+// ScheduleStop is one thing the planner wants to happen during a day, in visiting order.
+type ScheduleStop struct {
+	ActivityType string
+	EntityID     int
+	Category     string
+	Meal         string
+	Latitude     float64
+	Longitude    float64
+	HasLocation  bool
+	Duration     time.Duration
+	Notes        string
+}
+
+type ScheduledStop struct {
+	ScheduleStop
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type MealWindow struct {
+	Earliest time.Duration
+	Latest   time.Duration
+	Duration time.Duration
+}
+
+// DayScheduleConfig holds the bounds the scheduler works within.
+// Clock values are offsets from midnight of the itinerary day.
+type DayScheduleConfig struct {
+	DayStart             time.Duration
+	DayEnd               time.Duration
+	DepartureFlightStart time.Duration
+	AirportBuffer        time.Duration
+
+	TravelSpeedKmh  float64
+	DefaultTransfer time.Duration
+	SlotRounding    time.Duration
+
+	Lunch  MealWindow
+	Dinner MealWindow
+
+	CategoryDurations         map[string]time.Duration
+	DefaultAttractionDuration time.Duration
+	HotelDuration             time.Duration
+	EventDuration             time.Duration
+}
+
+func DefaultDayScheduleConfig() DayScheduleConfig {
+	return DayScheduleConfig{
+		DayStart:             9 * time.Hour,
+		DayEnd:               22 * time.Hour,
+		DepartureFlightStart: 18 * time.Hour,
+		AirportBuffer:        3 * time.Hour,
+
+		TravelSpeedKmh:  20,
+		DefaultTransfer: 20 * time.Minute,
+		SlotRounding:    5 * time.Minute,
+
+		Lunch:  MealWindow{Earliest: 12 * time.Hour, Latest: 15 * time.Hour, Duration: 75 * time.Minute},
+		Dinner: MealWindow{Earliest: 18*time.Hour + 30*time.Minute, Latest: 21*time.Hour + 30*time.Minute, Duration: 90 * time.Minute},
+
+		CategoryDurations: map[string]time.Duration{
+			models.PrefMuseum:     150 * time.Minute,
+			models.PrefGallery:    90 * time.Minute,
+			models.PrefHistoric:   90 * time.Minute,
+			models.PrefAttraction: 90 * time.Minute,
+			models.PrefMonument:   45 * time.Minute,
+			models.PrefViewpoint:  45 * time.Minute,
+		},
+		DefaultAttractionDuration: 90 * time.Minute,
+		HotelDuration:             time.Hour,
+		EventDuration:             90 * time.Minute,
+	}
+}
+
+type DayScheduler struct {
+	cfg DayScheduleConfig
+}
+
+func NewDayScheduler(cfg DayScheduleConfig) *DayScheduler {
+	return &DayScheduler{cfg: cfg}
+}
+
+// Schedule lays the stops out back to back, adding travel time between them.
+// Optional stops that cannot fit inside their window or the day bounds are dropped;
+// flights and hotel stays are always kept.
+func (d *DayScheduler) Schedule(day time.Time, stops []ScheduleStop) []ScheduledStop {
+	year, month, date := day.Date()
+	midnight := time.Date(year, month, date, 0, 0, 0, 0, day.Location())
+	dayEnd := midnight.Add(d.cfg.DayEnd)
+
+	cursor := midnight.Add(d.cfg.DayStart)
+	var prev *ScheduleStop
+	var result []ScheduledStop
+
+	for i := range stops {
+		stop := stops[i]
+		duration := d.durationFor(stop)
+		isDeparture := stop.ActivityType == "flight" && i == len(stops)-1 && i > 0
+
+		start := cursor
+		if prev != nil {
+			start = start.Add(d.travelTime(*prev, stop))
+		}
+		start = d.roundUp(start, midnight)
+
+		// Leave room for the trip to the airport before the return flight.
+		deadline := dayEnd
+		if n := len(stops) - 1; n > i && stops[n].ActivityType == "flight" {
+			deadline = midnight.Add(d.cfg.DepartureFlightStart - d.cfg.AirportBuffer)
+		}
+
+		switch {
+		case isDeparture:
+			if fixed := midnight.Add(d.cfg.DepartureFlightStart); start.Before(fixed) {
+				start = fixed
+			}
+
+		case stop.Meal != "":
+			window := d.mealWindow(stop.Meal)
+			if earliest := midnight.Add(window.Earliest); start.Before(earliest) {
+				start = earliest
+			}
+			if start.After(midnight.Add(window.Latest)) || start.Add(duration).After(deadline) {
+				slog.Debug("Dropping meal outside its window", "meal", stop.Meal, "entity_id", stop.EntityID, "start", start)
+				continue
+			}
+
+		case stop.ActivityType == "attraction" || stop.ActivityType == "event":
+			if start.Add(duration).After(deadline) {
+				slog.Debug("Dropping stop that does not fit the day", "type", stop.ActivityType, "entity_id", stop.EntityID, "start", start)
+				continue
+			}
+		}
+
+		end := start.Add(duration)
+		result = append(result, ScheduledStop{ScheduleStop: stop, StartTime: start, EndTime: end})
+		cursor = end
+		prev = &stops[i]
+	}
+
+	return result
+}
+
+func (d *DayScheduler) durationFor(stop ScheduleStop) time.Duration {
+	if stop.Duration > 0 {
+		return stop.Duration
+	}
+	if stop.Meal != "" {
+		return d.mealWindow(stop.Meal).Duration
+	}
+
+	switch stop.ActivityType {
+	case "attraction":
+		if dur, ok := d.cfg.CategoryDurations[strings.ToLower(stop.Category)]; ok {
+			return dur
+		}
+		return d.cfg.DefaultAttractionDuration
+	case "hotel":
+		return d.cfg.HotelDuration
+	case "restaurant":
+		return d.cfg.Lunch.Duration
+	default:
+		return d.cfg.EventDuration
+	}
+}
+
+func (d *DayScheduler) mealWindow(meal string) MealWindow {
+	if meal == MealDinner {
+		return d.cfg.Dinner
+	}
+	return d.cfg.Lunch
+}
+
+// travelTime estimates the transfer between two stops from their coordinates,
+// falling back to a flat transfer when either side has no location (hotels, flights, events).
+func (d *DayScheduler) travelTime(from, to ScheduleStop) time.Duration {
+	if !from.HasLocation || !to.HasLocation || d.cfg.TravelSpeedKmh <= 0 {
+		return d.cfg.DefaultTransfer
+	}
+
+	km := calculateDistance(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+	return time.Duration(km / d.cfg.TravelSpeedKmh * float64(time.Hour))
+}
+
+func (d *DayScheduler) roundUp(t time.Time, midnight time.Time) time.Time {
+	if d.cfg.SlotRounding <= 0 {
+		return t
+	}
+	offset := t.Sub(midnight)
+	if rem := offset % d.cfg.SlotRounding; rem != 0 {
+		offset += d.cfg.SlotRounding - rem
+	}
+	return midnight.Add(offset)
+}
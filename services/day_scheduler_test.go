@@ -0,0 +1,80 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+	"travel-planning/models"
+)
+
+func TestDaySchedulerSchedule(t *testing.T) {
+	museum := ScheduleStop{ActivityType: "attraction", Category: models.PrefMuseum}
+	hotel := ScheduleStop{ActivityType: "hotel"}
+	flight := ScheduleStop{ActivityType: "flight"}
+	lunch := ScheduleStop{ActivityType: "restaurant", Meal: MealLunch}
+	dinner := ScheduleStop{ActivityType: "restaurant", Meal: MealDinner}
+	long := func(d time.Duration) ScheduleStop {
+		return ScheduleStop{ActivityType: "attraction", Duration: d}
+	}
+	at := func(lat float64) ScheduleStop {
+		s := museum
+		s.Latitude, s.HasLocation = lat, true
+		return s
+	}
+
+	tests := []struct {
+		name  string
+		stops []ScheduleStop
+		want  []string
+	}{
+		{
+			"lunch waits for its window",
+			[]ScheduleStop{museum, lunch},
+			[]string{"attraction 09:00-11:30", "restaurant 12:00-13:15"},
+		},
+		{
+			"lunch after its window is dropped",
+			[]ScheduleStop{long(6 * time.Hour), lunch, dinner},
+			[]string{"attraction 09:00-15:00", "restaurant 18:30-20:00"},
+		},
+		{
+			"travel time from coordinates",
+			[]ScheduleStop{at(0), at(0.08)},
+			[]string{"attraction 09:00-11:30", "attraction 12:00-14:30"},
+		},
+		{
+			"departure flight waits for its fixed time",
+			[]ScheduleStop{hotel, museum, flight},
+			[]string{"hotel 09:00-10:00", "attraction 10:20-12:50", "flight 18:00-19:30"},
+		},
+		{
+			"attraction past the airport cutoff is dropped",
+			[]ScheduleStop{hotel, long(5 * time.Hour), flight},
+			[]string{"hotel 09:00-10:00", "flight 18:00-19:30"},
+		},
+		{
+			"arrival flight keeps its slot",
+			[]ScheduleStop{flight, hotel},
+			[]string{"flight 09:00-10:30", "hotel 10:50-11:50"},
+		},
+		{
+			"day end drops attractions but keeps the hotel",
+			[]ScheduleStop{long(12 * time.Hour), museum, hotel},
+			[]string{"attraction 09:00-21:00", "hotel 21:20-22:20"},
+		},
+	}
+
+	scheduler := NewDayScheduler(DefaultDayScheduleConfig())
+	day := time.Date(2026, time.May, 4, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range scheduler.Schedule(day, tt.stops) {
+				got = append(got, s.ActivityType+" "+s.StartTime.Format("15:04")+"-"+s.EndTime.Format("15:04"))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Schedule = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"strconv"
+	"travel-planning/models"
+)
+
+// Entities without coordinates cannot be drawn on a map and are left out of the collections.
+
+func AttractionsGeoJSON(attractions []models.Attraction) models.FeatureCollection {
+	fc := models.NewFeatureCollection()
+	for _, a := range attractions {
+		if a.Latitude == 0 && a.Longitude == 0 {
+			continue
+		}
+		fc.Features = append(fc.Features, models.NewPointFeature(int64(a.AttractionID), a.Latitude, a.Longitude, map[string]interface{}{
+			"kind":      "attraction",
+			"name":      a.Name,
+			"city_id":   a.CityID,
+			"category":  a.Category,
+			"rating":    a.Rating,
+			"entry_fee": a.EntryFee,
+			"website":   a.Website,
+		}))
+	}
+	return fc
+}
+
+func HotelsGeoJSON(hotels []models.Hotel) models.FeatureCollection {
+	fc := models.NewFeatureCollection()
+	for _, h := range hotels {
+		if h.Latitude == 0 && h.Longitude == 0 {
+			continue
+		}
+		fc.Features = append(fc.Features, models.NewPointFeature(int64(h.HotelID), h.Latitude, h.Longitude, map[string]interface{}{
+			"kind":            "hotel",
+			"name":            h.Name,
+			"city_id":         h.CityID,
+			"address":         h.Address,
+			"stars":           h.Stars,
+			"rating":          h.Rating,
+			"price_per_night": h.PricePerNight,
+			"website":         h.Website,
+		}))
+	}
+	return fc
+}
+
+func RestaurantsGeoJSON(restaurants []models.Restaurant) models.FeatureCollection {
+	fc := models.NewFeatureCollection()
+	for _, r := range restaurants {
+		if r.Latitude == 0 && r.Longitude == 0 {
+			continue
+		}
+		fc.Features = append(fc.Features, models.NewPointFeature(int64(r.RestaurantID), r.Latitude, r.Longitude, map[string]interface{}{
+			"kind":        "restaurant",
+			"name":        r.Name,
+			"city_id":     r.CityID,
+			"cuisine":     r.Cuisine,
+			"rating":      r.Rating,
+			"price_range": r.PriceRange,
+			"website":     r.Website,
+		}))
+	}
+	return fc
+}
+
+// ExportItineraryGeoJSON returns the trip's located activities as point features in
+// itinerary order, keyed by activity ID.
+func (s *TripPlanningService) ExportItineraryGeoJSON(tripID int, filter models.ResourceFilter) (models.FeatureCollection, error) {
+	fc := models.NewFeatureCollection()
+	export, err := s.loadTripExport(tripID)
+	if err != nil {
+		return fc, err
+	}
+
+	seenHotels := make(map[int64]bool)
+	for _, day := range export.Days {
+		if filter.CityID > 0 && day.City.CityID != filter.CityID {
+			continue
+		}
+		for _, a := range day.Activities {
+			checkIn := a.HotelID.Valid && !seenHotels[a.HotelID.Int64]
+			if a.HotelID.Valid {
+				seenHotels[a.HotelID.Int64] = true
+			}
+			if !hasLocation(a) || (filter.BBox != nil && !filter.BBox.Contains(a.EntityLatitude, a.EntityLongitude)) {
+				continue
+			}
+
+			properties := map[string]interface{}{
+				"kind":          "activity",
+				"activity_type": a.ActivityType,
+				"name":          activityTitle(a, checkIn),
+				"day":           day.Day.DayNumber,
+				"order":         a.OrderNumber,
+				"city_id":       day.City.CityID,
+				"start_time":    localTimeValue(a.StartTime, day.Location),
+				"end_time":      localTimeValue(a.EndTime, day.Location),
+				"rating":        a.EntityRating,
+				"notes":         a.Notes,
+			}
+			switch a.ActivityType {
+			case "attraction":
+				properties["category"] = a.EntityDetail
+				if fee, err := strconv.ParseFloat(a.EntityExtra, 64); err == nil {
+					properties["entry_fee"] = fee
+				}
+			case "restaurant":
+				properties["cuisine"] = a.EntityDetail
+				properties["price_range"] = a.EntityExtra
+			case "hotel":
+				properties["address"] = a.EntityDetail
+			}
+			fc.Features = append(fc.Features, models.NewPointFeature(a.ActivityID, a.EntityLatitude, a.EntityLongitude, properties))
+		}
+	}
+	return fc, nil
+}
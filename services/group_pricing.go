@@ -0,0 +1,45 @@
+package services
+
+import "math"
+
+const (
+	// Children fly at a reduced fare and get discounted entry at most attractions.
+	childFlightFareFactor = 0.75
+	childEntryFeeFactor   = 0.50
+
+	adultsPerRoom   = 2
+	childrenPerRoom = 2
+)
+
+// TravelerGroup is the party a trip is priced for.
+type TravelerGroup struct {
+	Adults   int
+	Children int
+}
+
+func (g TravelerGroup) Travelers() int {
+	return g.Adults + g.Children
+}
+
+// FlightFares is how many adult fares one flight costs the group.
+func (g TravelerGroup) FlightFares() float64 {
+	return float64(g.Adults) + float64(g.Children)*childFlightFareFactor
+}
+
+// EntryFees is how many adult tickets one attraction visit costs the group.
+func (g TravelerGroup) EntryFees() float64 {
+	return float64(g.Adults) + float64(g.Children)*childEntryFeeFactor
+}
+
+// Rooms is the number of hotel rooms needed: two adults per room, with up to two
+// children sharing each room.
+func (g TravelerGroup) Rooms() int {
+	rooms := int(math.Ceil(float64(g.Adults) / adultsPerRoom))
+	if byChildren := int(math.Ceil(float64(g.Children) / childrenPerRoom)); byChildren > rooms {
+		rooms = byChildren
+	}
+	if rooms < 1 {
+		rooms = 1
+	}
+	return rooms
+}
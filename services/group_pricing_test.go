@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+	"travel-planning/models"
+)
+
+func TestTravelerGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		group       TravelerGroup
+		travelers   int
+		flightFares float64
+		entryFees   float64
+		rooms       int
+	}{
+		{"solo", TravelerGroup{Adults: 1}, 1, 1, 1, 1},
+		{"couple", TravelerGroup{Adults: 2}, 2, 2, 2, 1},
+		{"three adults", TravelerGroup{Adults: 3}, 3, 3, 3, 2},
+		{"family of four", TravelerGroup{Adults: 2, Children: 2}, 4, 3.5, 3, 1},
+		{"children need more rooms than adults", TravelerGroup{Adults: 1, Children: 3}, 4, 3.25, 2.5, 2},
+		{"empty group still books a room", TravelerGroup{}, 0, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := tt.group
+			if got := g.Travelers(); got != tt.travelers {
+				t.Errorf("Travelers = %d, want %d", got, tt.travelers)
+			}
+			if got := g.FlightFares(); got != tt.flightFares {
+				t.Errorf("FlightFares = %v, want %v", got, tt.flightFares)
+			}
+			if got := g.EntryFees(); got != tt.entryFees {
+				t.Errorf("EntryFees = %v, want %v", got, tt.entryFees)
+			}
+			if got := g.Rooms(); got != tt.rooms {
+				t.Errorf("Rooms = %d, want %d", got, tt.rooms)
+			}
+		})
+	}
+}
+
+func TestTravelerGroupForTrip(t *testing.T) {
+	tests := []struct {
+		trip models.Trip
+		want TravelerGroup
+	}{
+		{models.Trip{}, TravelerGroup{Adults: 1}},
+		{models.Trip{Adults: 0, Children: 2}, TravelerGroup{Adults: 1, Children: 2}},
+		{models.Trip{Adults: 3, Children: 1}, TravelerGroup{Adults: 3, Children: 1}},
+	}
+	for _, tt := range tests {
+		if got := travelerGroup(&tt.trip); got != tt.want {
+			t.Errorf("travelerGroup(adults=%d, children=%d) = %+v, want %+v", tt.trip.Adults, tt.trip.Children, got, tt.want)
+		}
+	}
+}
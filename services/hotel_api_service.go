@@ -35,8 +35,12 @@ func NewHotelAPIService(cache *cache.RedisCache) *HotelAPIService {
 
 type OverpassHotelResponse struct {
 	Elements []struct {
-		Lat  float64           `json:"lat"`
-		Lon  float64           `json:"lon"`
+		Lat    float64 `json:"lat"`
+		Lon    float64 `json:"lon"`
+		Center struct {
+			Lat float64 `json:"lat"`
+			Lon float64 `json:"lon"`
+		} `json:"center"`
 		Tags map[string]string `json:"tags"`
 	} `json:"elements"`
 }
@@ -94,6 +98,11 @@ func (s *HotelAPIService) FetchHotelsByCity(cityID int, lat, lon float64) ([]*mo
 			continue
 		}
 
+		hotelLat, hotelLon := el.Lat, el.Lon
+		if hotelLat == 0 && hotelLon == 0 {
+			hotelLat, hotelLon = el.Center.Lat, el.Center.Lon
+		}
+
 		stars, _ := strconv.Atoi(el.Tags["stars"])
 		if stars == 0 {
 			stars = 3 + rand.IntN(3)
@@ -150,6 +159,8 @@ func (s *HotelAPIService) FetchHotelsByCity(cityID int, lat, lon float64) ([]*mo
 			CityID:        cityID,
 			Name:          name,
 			Address:       address,
+			Latitude:      hotelLat,
+			Longitude:     hotelLon,
 			Stars:         stars,
 			Rating:        rating,
 			PricePerNight: price,
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"travel-planning/internal/pdf"
+	"travel-planning/models"
+)
+
+var ErrItineraryNotConfirmed = errors.New("trip itinerary is not confirmed yet")
+
+const (
+	bookletMargin   = 50.0
+	bookletTimeCol  = 95.0
+	bookletLineGap  = 1.35
+	bookletDateLong = "Monday 2 January 2006"
+)
+
+// TripCosts is what a confirmed trip costs the whole group, derived from the flights,
+// hotels and attractions that ended up in its itinerary.
+type TripCosts struct {
+	Flights    float64
+	Hotels     float64
+	Activities float64
+	Total      float64
+	Budget     float64
+}
+
+// ExportItineraryPDF renders a printable booklet of a confirmed trip: a cover page, one
+// section per day and a cost summary.
+func (s *TripPlanningService) ExportItineraryPDF(tripID int) ([]byte, error) {
+	export, err := s.loadTripExport(tripID)
+	if err != nil {
+		return nil, err
+	}
+	trip := export.Trip
+	if trip.Status != "Confirmed" && trip.Status != "Completed" {
+		return nil, ErrItineraryNotConfirmed
+	}
+
+	costs, err := s.tripCosts(export)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &booklet{doc: pdf.New(trip.Title)}
+	b.cover(export)
+	b.newPage()
+	seenHotels := make(map[int64]bool)
+	for _, day := range export.Days {
+		b.day(day, seenHotels)
+	}
+	b.costSummary(costs)
+
+	return b.doc.Bytes()
+}
+
+// tripCosts prices the itinerary for the trip's group: every distinct flight, each leg's
+// hotel for the leg's nights and the entry fee of every scheduled attraction.
+func (s *TripPlanningService) tripCosts(export *tripExport) (TripCosts, error) {
+	group := travelerGroup(export.Trip)
+	costs := TripCosts{Budget: export.Trip.TotalPrice}
+
+	flights := make(map[int64]bool)
+	legHotels := make(map[int]int64)
+	for _, day := range export.Days {
+		for _, a := range day.Activities {
+			switch {
+			case a.FlightID.Valid && !flights[a.FlightID.Int64]:
+				flights[a.FlightID.Int64] = true
+				flight, err := s.FlightRepo.GetFlightByID(int(a.FlightID.Int64))
+				if err != nil {
+					return costs, err
+				}
+				if flight != nil {
+					costs.Flights += flight.Price * group.FlightFares()
+				}
+			case a.HotelID.Valid:
+				if _, ok := legHotels[day.Leg]; !ok {
+					legHotels[day.Leg] = a.HotelID.Int64
+				}
+			case a.AttractionID.Valid:
+				if fee, err := strconv.ParseFloat(a.EntityExtra, 64); err == nil {
+					costs.Activities += fee * group.EntryFees()
+				}
+			}
+		}
+	}
+
+	for li, hotelID := range legHotels {
+		hotel, err := s.HotelRepo.GetHotelByID(int(hotelID))
+		if err != nil {
+			return costs, err
+		}
+		if hotel != nil {
+			costs.Hotels += hotel.PricePerNight * float64(export.Legs[li].Nights()*group.Rooms())
+		}
+	}
+
+	costs.Total = costs.Flights + costs.Hotels + costs.Activities
+	return costs, nil
+}
+
+// booklet lays text out top to bottom, starting a new page when the current one is full.
+type booklet struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+func (b *booklet) newPage() {
+	b.page = b.doc.AddPage()
+	b.y = bookletMargin
+}
+
+func (b *booklet) ensure(height float64) {
+	if b.page == nil || b.y+height > pdf.PageHeight-bookletMargin {
+		b.newPage()
+	}
+}
+
+// paragraph writes wrapped text starting at x and returns the number of lines used.
+func (b *booklet) paragraph(x float64, font pdf.Font, size float64, text string) int {
+	lines := pdf.Wrap(font, size, pdf.PageWidth-bookletMargin-x, text)
+	for _, line := range lines {
+		b.ensure(size * bookletLineGap)
+		b.y += size * bookletLineGap
+		b.page.Text(x, b.y, font, size, line)
+	}
+	return len(lines)
+}
+
+func (b *booklet) cover(export *tripExport) {
+	trip := export.Trip
+	b.newPage()
+
+	var cities []string
+	seen := make(map[int]bool)
+	for _, day := range export.Days {
+		if !seen[day.City.CityID] && day.City.Name != "" {
+			seen[day.City.CityID] = true
+			cities = append(cities, day.City.Name)
+		}
+	}
+
+	b.page.SetGray(0.15)
+	b.page.FillRect(0, 0, pdf.PageWidth, 300)
+	b.page.SetGray(1)
+	b.y = 180
+	b.paragraph(bookletMargin, pdf.HelveticaBold, 30, trip.Title)
+	b.y += 10
+	b.paragraph(bookletMargin, pdf.Helvetica, 16, strings.Join(cities, " · "))
+
+	b.page.SetGray(0)
+	b.y = 350
+	b.paragraph(bookletMargin, pdf.HelveticaBold, 14, fmt.Sprintf("%s – %s",
+		trip.StartDate.Format("2 January 2006"), trip.EndDate.Format("2 January 2006")))
+	b.y += 6
+
+	travelers := pluralize(trip.Adults, "adult", "adults")
+	if trip.Children > 0 {
+		travelers += ", " + pluralize(trip.Children, "child", "children")
+	}
+	details := []string{
+		pluralize(len(export.Days), "day", "days"),
+		travelers,
+	}
+	if trip.Tier != "" {
+		details = append(details, trip.Tier+" tier")
+	}
+	b.paragraph(bookletMargin, pdf.Helvetica, 12, strings.Join(details, " · "))
+
+	b.page.SetGray(0.45)
+	b.page.Text(bookletMargin, pdf.PageHeight-bookletMargin, pdf.Helvetica, 9,
+		"Generated on "+time.Now().Format("2 January 2006"))
+	b.page.SetGray(0)
+}
+
+func (b *booklet) day(day exportDay, seenHotels map[int64]bool) {
+	b.ensure(90)
+	b.y += 10
+	b.page.SetGray(0.9)
+	b.page.FillRect(bookletMargin-6, b.y, pdf.PageWidth-2*bookletMargin+12, 26)
+	b.page.SetGray(0)
+	b.page.Text(bookletMargin, b.y+17, pdf.HelveticaBold, 13,
+		fmt.Sprintf("Day %d · %s", day.Day.DayNumber, day.Day.Date.Format(bookletDateLong)))
+	if day.City.Name != "" {
+		w := pdf.TextWidth(pdf.Helvetica, 11, day.City.Name)
+		b.page.Text(pdf.PageWidth-bookletMargin-w, b.y+17, pdf.Helvetica, 11, day.City.Name)
+	}
+	b.y += 30
+
+	if day.Day.RouteKm > 0 {
+		b.page.SetGray(0.35)
+		b.paragraph(bookletMargin, pdf.Helvetica, 9, fmt.Sprintf("Sightseeing loop: %.1f km", day.Day.RouteKm))
+		b.page.SetGray(0)
+	}
+	if len(day.Activities) == 0 {
+		b.paragraph(bookletMargin, pdf.Helvetica, 10, "Nothing planned for this day.")
+	}
+
+	for _, a := range day.Activities {
+		checkIn := a.HotelID.Valid && !seenHotels[a.HotelID.Int64]
+		if a.HotelID.Valid {
+			seenHotels[a.HotelID.Int64] = true
+		}
+
+		b.ensure(40)
+		b.y += 8
+		b.page.Text(bookletMargin, b.y+11*bookletLineGap, pdf.HelveticaBold, 10,
+			fmt.Sprintf("%s – %s", a.StartTime.Format("15:04"), a.EndTime.Format("15:04")))
+		b.paragraph(bookletMargin+bookletTimeCol, pdf.HelveticaBold, 11, activityTitle(a, checkIn))
+
+		b.page.SetGray(0.35)
+		if facts := activityFacts(a); facts != "" {
+			b.paragraph(bookletMargin+bookletTimeCol, pdf.Helvetica, 9, facts)
+		}
+		b.page.SetGray(0)
+		if a.Notes != "" {
+			b.paragraph(bookletMargin+bookletTimeCol, pdf.Helvetica, 9, a.Notes)
+		}
+	}
+}
+
+// activityFacts is the detail line under an activity: category or cuisine, rating and price.
+func activityFacts(a *models.ItineraryActivity) string {
+	var facts []string
+	if a.EntityDetail != "" {
+		facts = append(facts, a.EntityDetail)
+	}
+	if a.EntityRating > 0 {
+		facts = append(facts, fmt.Sprintf("Rated %.1f/5", a.EntityRating))
+	}
+	switch {
+	case a.ActivityType == "attraction" && a.AttractionID.Valid:
+		if fee, err := strconv.ParseFloat(a.EntityExtra, 64); err == nil && fee > 0 {
+			facts = append(facts, fmt.Sprintf("Entry fee %.2f", fee))
+		} else if err == nil {
+			facts = append(facts, "Free entry")
+		}
+	case a.ActivityType == "restaurant" && a.EntityExtra != "":
+		facts = append(facts, "Price range "+a.EntityExtra)
+	}
+	return strings.Join(facts, " · ")
+}
+
+func (b *booklet) costSummary(costs TripCosts) {
+	b.ensure(170)
+	b.y += 24
+	b.paragraph(bookletMargin, pdf.HelveticaBold, 16, "Cost summary")
+	b.y += 6
+
+	rows := []struct {
+		label  string
+		amount float64
+		bold   bool
+	}{
+		{"Flights", costs.Flights, false},
+		{"Hotels", costs.Hotels, false},
+		{"Attraction tickets", costs.Activities, false},
+		{"Total", costs.Total, true},
+		{"Budget", costs.Budget, false},
+		{"Left for meals and extras", costs.Budget - costs.Total, false},
+	}
+	right := pdf.PageWidth - bookletMargin
+	for i, row := range rows {
+		font := pdf.Helvetica
+		if row.bold {
+			font = pdf.HelveticaBold
+			b.page.Line(bookletMargin, b.y+4, right, b.y+4, 0.5)
+			b.y += 4
+		}
+		b.y += 16
+		b.page.Text(bookletMargin, b.y, font, 11, row.label)
+		amount := fmt.Sprintf("%.2f", row.amount)
+		b.page.Text(right-pdf.TextWidth(font, 11, amount), b.y, font, 11, amount)
+		if row.bold && i+1 < len(rows) {
+			b.y += 8
+		}
+	}
+}
+
+func pluralize(n int, one, many string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, one)
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+	"travel-planning/models"
+)
+
+var ErrActivityNotFound = errors.New("activity not found")
+
+var ErrInvalidActivityEdit = errors.New("invalid activity edit")
+
+var ErrActivityConflict = errors.New("activity overlaps another one")
+
+const maxActivityAlternatives = 5
+
+// AddActivity inserts an attraction or restaurant into an itinerary day.
+func (s *TripPlanningService) AddActivity(itineraryID int64, req models.ActivityCreateRequest) (*models.ItineraryActivity, error) {
+	l := slog.With("itinerary_id", itineraryID, "type", req.ActivityType, "entity_id", req.EntityID)
+
+	tx, err := s.TripRepo.GetConn().Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	day, err := s.ItineraryRepo.GetForUpdate(tx, itineraryID)
+	if err != nil {
+		return nil, err
+	}
+	if day == nil {
+		return nil, ErrTripNotFound
+	}
+
+	activity := &models.ItineraryActivity{
+		ItineraryID: itineraryID,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Notes:       req.Notes,
+	}
+	if err := s.setActivityEntity(activity, day.TripID, req.ActivityType, req.EntityID); err != nil {
+		return nil, err
+	}
+	if err := s.checkSlot(tx, day, req.StartTime, req.EndTime, 0); err != nil {
+		return nil, err
+	}
+
+	activityID, err := s.ItineraryActivitiesRepo.Insert(tx, activity)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ItineraryActivitiesRepo.Renumber(tx, itineraryID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit activity: %w", err)
+	}
+
+	l.Info("Activity added to itinerary", "activity_id", activityID)
+	return s.ItineraryActivitiesRepo.GetByID(activityID)
+}
+
+// MoveActivity reschedules an activity within its day or onto another day of the same trip.
+func (s *TripPlanningService) MoveActivity(activityID int64, req models.ActivityMoveRequest) (*models.ItineraryActivity, error) {
+	l := slog.With("activity_id", activityID)
+
+	activity, err := s.editableActivity(activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.TripRepo.GetConn().Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock both days in a fixed order so two opposite moves cannot deadlock.
+	sourceID, targetID := activity.ItineraryID, activity.ItineraryID
+	if req.ItineraryID > 0 {
+		targetID = req.ItineraryID
+	}
+	first, second := sourceID, targetID
+	if first > second {
+		first, second = second, first
+	}
+	locked := make(map[int64]*models.TripItinerary)
+	for _, id := range []int64{first, second} {
+		if locked[id] != nil {
+			continue
+		}
+		day, err := s.ItineraryRepo.GetForUpdate(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if day == nil {
+			return nil, fmt.Errorf("%w: itinerary day %d does not exist", ErrInvalidActivityEdit, id)
+		}
+		locked[id] = day
+	}
+	if activity, err = s.lockedActivity(tx, activityID, sourceID); err != nil {
+		return nil, err
+	}
+	source, target := locked[sourceID], locked[targetID]
+	if source.TripID != target.TripID {
+		return nil, fmt.Errorf("%w: activities can only move between days of the same trip", ErrInvalidActivityEdit)
+	}
+
+	// Without explicit times the activity keeps its clock time and duration on the new day.
+	shift := dateOnly(target.Date).Sub(dateOnly(source.Date))
+	start, end := activity.StartTime.Add(shift), activity.EndTime.Add(shift)
+	if req.StartTime != nil {
+		duration := end.Sub(start)
+		start = *req.StartTime
+		end = start.Add(duration)
+	}
+	if req.EndTime != nil {
+		end = *req.EndTime
+	}
+
+	if err := s.checkSlot(tx, target, start, end, activityID); err != nil {
+		return nil, err
+	}
+	if err := s.ItineraryActivitiesRepo.UpdatePlacement(tx, activityID, targetID, start, end); err != nil {
+		return nil, err
+	}
+	for id := range locked {
+		if err := s.ItineraryActivitiesRepo.Renumber(tx, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit move: %w", err)
+	}
+
+	l.Info("Activity moved", "from_itinerary", sourceID, "to_itinerary", targetID, "start", start)
+	return s.ItineraryActivitiesRepo.GetByID(activityID)
+}
+
+// GetActivityAlternatives lists the closest attractions not yet in the trip, or the closest
+// other restaurants, that the activity can be swapped for.
+func (s *TripPlanningService) GetActivityAlternatives(activityID int64) ([]models.ActivityAlternative, error) {
+	activity, err := s.editableActivity(activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	alternatives := []models.ActivityAlternative{}
+	switch {
+	case activity.AttractionID.Valid:
+		current, err := s.AttractionRepo.GetByID(int(activity.AttractionID.Int64))
+		if err != nil || current == nil {
+			return nil, fmt.Errorf("%w: attraction of this activity no longer exists", ErrInvalidActivityEdit)
+		}
+		tripID, err := s.ItineraryActivitiesRepo.GetTripIDByActivityID(activityID)
+		if err != nil {
+			return nil, err
+		}
+		scheduled, err := s.ItineraryActivitiesRepo.GetAttractionIDsByTripID(tripID, 0)
+		if err != nil {
+			return nil, err
+		}
+		nearby, err := s.AttractionRepo.GetNearby(current.CityID, current.Latitude, current.Longitude, scheduled, maxActivityAlternatives)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range nearby {
+			alternatives = append(alternatives, models.ActivityAlternative{
+				ActivityType: "attraction",
+				EntityID:     a.AttractionID,
+				Name:         a.Name,
+				Detail:       a.Category,
+				Rating:       a.Rating,
+				DistanceKm:   roundKm(calculateDistance(current.Latitude, current.Longitude, a.Latitude, a.Longitude)),
+			})
+		}
+
+	case activity.RestaurantID.Valid:
+		current, err := s.RestaurantRepo.GetByID(int(activity.RestaurantID.Int64))
+		if err != nil || current == nil {
+			return nil, fmt.Errorf("%w: restaurant of this activity no longer exists", ErrInvalidActivityEdit)
+		}
+		nearby, err := s.RestaurantRepo.GetNearby(current.CityID, current.Latitude, current.Longitude, []int{current.RestaurantID}, maxActivityAlternatives)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range nearby {
+			alternatives = append(alternatives, models.ActivityAlternative{
+				ActivityType: "restaurant",
+				EntityID:     r.RestaurantID,
+				Name:         r.Name,
+				Detail:       r.Cuisine,
+				Rating:       r.Rating,
+				DistanceKm:   roundKm(calculateDistance(current.Latitude, current.Longitude, r.Latitude, r.Longitude)),
+			})
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: only attraction and restaurant activities have alternatives", ErrInvalidActivityEdit)
+	}
+	return alternatives, nil
+}
+
+// ReplaceActivity swaps the attraction or restaurant of an activity for one of its alternatives,
+// keeping the time slot.
+func (s *TripPlanningService) ReplaceActivity(activityID int64, req models.ActivityReplaceRequest) (*models.ItineraryActivity, error) {
+	alternatives, err := s.GetActivityAlternatives(activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var chosen *models.ActivityAlternative
+	for i := range alternatives {
+		if alternatives[i].EntityID == req.EntityID {
+			chosen = &alternatives[i]
+			break
+		}
+	}
+	if chosen == nil {
+		return nil, fmt.Errorf("%w: %d is not one of the nearby alternatives", ErrInvalidActivityEdit, req.EntityID)
+	}
+
+	activity, err := s.editableActivity(activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.TripRepo.GetConn().Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := s.ItineraryRepo.GetForUpdate(tx, activity.ItineraryID); err != nil {
+		return nil, err
+	}
+	if activity, err = s.lockedActivity(tx, activityID, activity.ItineraryID); err != nil {
+		return nil, err
+	}
+
+	previous := activity.EntityName
+	activity.AttractionID = sql.NullInt64{}
+	activity.RestaurantID = sql.NullInt64{}
+	if chosen.ActivityType == "attraction" {
+		activity.AttractionID = sql.NullInt64{Int64: int64(chosen.EntityID), Valid: true}
+	} else {
+		activity.RestaurantID = sql.NullInt64{Int64: int64(chosen.EntityID), Valid: true}
+	}
+	activity.ActivityType = chosen.ActivityType
+	activity.Notes = fmt.Sprintf("Replaces %s.", previous)
+
+	if err := s.ItineraryActivitiesRepo.UpdateEntity(tx, activity); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit replacement: %w", err)
+	}
+
+	slog.Info("Activity replaced", "activity_id", activityID, "type", chosen.ActivityType, "entity_id", chosen.EntityID)
+	return s.ItineraryActivitiesRepo.GetByID(activityID)
+}
+
+// DeleteActivity removes an activity and closes the gap in the day's order numbers.
+func (s *TripPlanningService) DeleteActivity(activityID int64) error {
+	activity, err := s.editableActivity(activityID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.TripRepo.GetConn().Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := s.ItineraryRepo.GetForUpdate(tx, activity.ItineraryID); err != nil {
+		return err
+	}
+	if _, err := s.lockedActivity(tx, activityID, activity.ItineraryID); err != nil {
+		return err
+	}
+	if err := s.ItineraryActivitiesRepo.Delete(tx, activityID); err != nil {
+		return err
+	}
+	if err := s.ItineraryActivitiesRepo.Renumber(tx, activity.ItineraryID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit delete: %w", err)
+	}
+
+	slog.Info("Activity deleted", "activity_id", activityID, "itinerary_id", activity.ItineraryID)
+	return nil
+}
+
+// lockedActivity re-reads an activity once its day is locked, since it may have been deleted
+// or moved to another day between the first read and the lock.
+func (s *TripPlanningService) lockedActivity(tx *sql.Tx, activityID, itineraryID int64) (*models.ItineraryActivity, error) {
+	activity, err := s.ItineraryActivitiesRepo.GetForUpdate(tx, activityID)
+	if err != nil {
+		return nil, err
+	}
+	if activity == nil {
+		return nil, ErrActivityNotFound
+	}
+	if activity.ItineraryID != itineraryID {
+		return nil, fmt.Errorf("%w: activity was moved to another day meanwhile", ErrInvalidActivityEdit)
+	}
+	return activity, nil
+}
+
+// editableActivity loads an activity that can be edited by hand. Flights and hotel stays
+// are part of the booked logistics and stay as planned.
+func (s *TripPlanningService) editableActivity(activityID int64) (*models.ItineraryActivity, error) {
+	activity, err := s.ItineraryActivitiesRepo.GetByID(activityID)
+	if err != nil {
+		return nil, err
+	}
+	if activity == nil {
+		return nil, ErrActivityNotFound
+	}
+	if activity.ActivityType != "attraction" && activity.ActivityType != "restaurant" {
+		return nil, fmt.Errorf("%w: %s activities cannot be edited", ErrInvalidActivityEdit, activity.ActivityType)
+	}
+	return activity, nil
+}
+
+// setActivityEntity points a new activity at an attraction or restaurant in one of the trip's cities.
+func (s *TripPlanningService) setActivityEntity(activity *models.ItineraryActivity, tripID int, activityType string, entityID int) error {
+	trip, err := s.TripRepo.GetTripByID(tripID)
+	if err != nil {
+		return err
+	}
+	legs, err := s.tripLegs(trip)
+	if err != nil {
+		return err
+	}
+	inTrip := func(cityID int) bool {
+		for _, leg := range legs {
+			if leg.CityID == cityID {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch activityType {
+	case "attraction":
+		a, err := s.AttractionRepo.GetByID(entityID)
+		if err != nil {
+			return err
+		}
+		if a == nil || !inTrip(a.CityID) {
+			return fmt.Errorf("%w: attraction %d is not in a city of this trip", ErrInvalidActivityEdit, entityID)
+		}
+		activity.AttractionID = sql.NullInt64{Int64: int64(entityID), Valid: true}
+	case "restaurant":
+		r, err := s.RestaurantRepo.GetByID(entityID)
+		if err != nil {
+			return err
+		}
+		if r == nil || !inTrip(r.CityID) {
+			return fmt.Errorf("%w: restaurant %d is not in a city of this trip", ErrInvalidActivityEdit, entityID)
+		}
+		activity.RestaurantID = sql.NullInt64{Int64: int64(entityID), Valid: true}
+	default:
+		return fmt.Errorf("%w: activity_type must be attraction or restaurant", ErrInvalidActivityEdit)
+	}
+	activity.ActivityType = activityType
+	return nil
+}
+
+// checkSlot validates a time slot against the day and the other activities on it.
+func (s *TripPlanningService) checkSlot(tx *sql.Tx, day *models.TripItinerary, start, end time.Time, excludeID int64) error {
+	if start.IsZero() || !end.After(start) {
+		return fmt.Errorf("%w: end_time must be after start_time", ErrInvalidActivityEdit)
+	}
+	if !sameDate(start, day.Date) || end.After(dateOnly(day.Date).AddDate(0, 0, 1)) {
+		return fmt.Errorf("%w: the activity must take place on %s", ErrInvalidActivityEdit, day.Date.Format("2006-01-02"))
+	}
+
+	overlap, err := s.ItineraryActivitiesRepo.HasOverlap(tx, int64(day.ItineraryID), start, end, excludeID)
+	if err != nil {
+		return err
+	}
+	if overlap {
+		return fmt.Errorf("%w between %s and %s", ErrActivityConflict, start.Format("15:04"), end.Format("15:04"))
+	}
+	return nil
+}
+
+func roundKm(km float64) float64 {
+	return math.Round(km*10) / 10
+}
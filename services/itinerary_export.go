@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"time"
+	"travel-planning/models"
+)
+
+// exportDay is one itinerary day with its activities, ready to be rendered by an exporter.
+type exportDay struct {
+	Day        *models.TripItinerary
+	Leg        int
+	City       *models.City
+	Location   *time.Location
+	Activities []*models.ItineraryActivity
+}
+
+type tripExport struct {
+	Trip *models.Trip
+	Legs []models.TripLeg
+	Days []exportDay
+}
+
+// loadTripExport gathers a trip's days in order, each with the city and timezone of the
+// leg it belongs to. Activities stay in order_number order.
+func (s *TripPlanningService) loadTripExport(tripID int) (*tripExport, error) {
+	trip, err := s.TripRepo.GetTripByID(tripID)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.ItineraryRepo.GetItineraryDaysByTripID(tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load itinerary days: %w", err)
+	}
+	legs, err := s.tripLegs(trip)
+	if err != nil {
+		return nil, fmt.Errorf("could not load trip legs: %w", err)
+	}
+
+	cities := make(map[int]*models.City)
+	export := &tripExport{Trip: trip, Legs: legs}
+	for _, day := range days {
+		li := legIndexFor(legs, day.Date)
+		cityID := legs[li].CityID
+		city, ok := cities[cityID]
+		if !ok {
+			if city, err = s.CityRepo.GetCityByID(cityID); err != nil {
+				return nil, err
+			}
+			if city == nil {
+				city = &models.City{CityID: cityID}
+			}
+			cities[cityID] = city
+		}
+
+		activities, err := s.ItineraryActivitiesRepo.GetActivitiesByItineraryID(day.ItineraryID)
+		if err != nil {
+			return nil, err
+		}
+		export.Days = append(export.Days, exportDay{
+			Day:        day,
+			Leg:        li,
+			City:       city,
+			Location:   cityLocation(city),
+			Activities: activities,
+		})
+	}
+	return export, nil
+}
+
+// cityLocation resolves the city's IANA timezone. Cities seeded without a usable one get
+// nil: their times are exported as floating local times instead of against a guessed offset.
+func cityLocation(city *models.City) *time.Location {
+	if city.Timezone == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(city.Timezone)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// inLocation reads a stored wall-clock time as local time of loc.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
+
+// utcTime converts a stored wall-clock time to UTC. Without a known timezone the time is no
+// instant at all, and it reports false.
+func utcTime(t time.Time, loc *time.Location) (time.Time, bool) {
+	if loc == nil {
+		return time.Time{}, false
+	}
+	return inLocation(t, loc).UTC(), true
+}
+
+// localTimeValue formats a stored wall-clock time for JSON: RFC 3339 with the city's offset
+// when its timezone is known, floating local time without an offset otherwise.
+func localTimeValue(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		return t.Format("2006-01-02T15:04:05")
+	}
+	return inLocation(t, loc).Format(time.RFC3339)
+}
+
+// activityTitle is a short human readable label for an activity, shared by the exporters.
+// checkIn marks the first appearance of a hotel during the trip.
+func activityTitle(a *models.ItineraryActivity, checkIn bool) string {
+	switch {
+	case a.ActivityType == "flight":
+		if a.EntityName != "" {
+			return "Flight: " + a.EntityName
+		}
+		return "Flight"
+	case a.ActivityType == "hotel" && checkIn:
+		return "Hotel check-in: " + a.EntityName
+	case a.ActivityType == "hotel":
+		return "Hotel: " + a.EntityName
+	case a.ActivityType == "restaurant" && a.StartTime.Hour() >= 17:
+		return "Dinner: " + a.EntityName
+	case a.ActivityType == "restaurant":
+		return "Lunch: " + a.EntityName
+	case a.EntityName != "":
+		return a.EntityName
+	default:
+		return "Free time"
+	}
+}
+
+func hasLocation(a *models.ItineraryActivity) bool {
+	return a.EntityLatitude != 0 || a.EntityLongitude != 0
+}
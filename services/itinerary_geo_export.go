@@ -0,0 +1,193 @@
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+	"travel-planning/models"
+)
+
+type gpxDocument struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	Name      string        `xml:"metadata>name"`
+	Time      string        `xml:"metadata>time"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+	Routes    []gpxRoute    `xml:"rte"`
+}
+
+type gpxWaypoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Time string  `xml:"time,omitempty"`
+	Name string  `xml:"name"`
+	Desc string  `xml:"desc,omitempty"`
+	Type string  `xml:"type,omitempty"`
+}
+
+type gpxRoute struct {
+	Name   string        `xml:"name"`
+	Number int           `xml:"number"`
+	Points []gpxWaypoint `xml:"rtept"`
+}
+
+type kmlDocument struct {
+	XMLName xml.Name    `xml:"kml"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	Name    string      `xml:"Document>name"`
+	Folders []kmlFolder `xml:"Document>Folder"`
+}
+
+type kmlFolder struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string       `xml:"name"`
+	Description string       `xml:"description,omitempty"`
+	TimeSpan    *kmlTimeSpan `xml:"TimeSpan,omitempty"`
+	Point       *kmlGeometry `xml:"Point,omitempty"`
+	LineString  *kmlGeometry `xml:"LineString,omitempty"`
+}
+
+type kmlTimeSpan struct {
+	Begin string `xml:"begin"`
+	End   string `xml:"end"`
+}
+
+type kmlGeometry struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// ExportItineraryGPX writes every located activity as a waypoint and each day as a route
+// through its stops in order_number order. Flights and events have no coordinates and are skipped.
+func (s *TripPlanningService) ExportItineraryGPX(tripID int) ([]byte, error) {
+	export, err := s.loadTripExport(tripID)
+	if err != nil {
+		return nil, err
+	}
+	return marshalXML(buildGPX(export, time.Now()))
+}
+
+// buildGPX lays out the GPX document of a loaded trip; now is its metadata time.
+func buildGPX(export *tripExport, now time.Time) gpxDocument {
+	doc := gpxDocument{
+		Version: "1.1",
+		Creator: "travel-planning",
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Name:    export.Trip.Title,
+		Time:    now.UTC().Format(time.RFC3339),
+	}
+
+	seenHotels := make(map[int64]bool)
+	for _, day := range export.Days {
+		route := gpxRoute{Name: dayLabel(day), Number: day.Day.DayNumber}
+		for _, a := range day.Activities {
+			checkIn := a.HotelID.Valid && !seenHotels[a.HotelID.Int64]
+			if a.HotelID.Valid {
+				seenHotels[a.HotelID.Int64] = true
+			}
+			if !hasLocation(a) {
+				continue
+			}
+
+			point := gpxWaypoint{
+				Lat:  a.EntityLatitude,
+				Lon:  a.EntityLongitude,
+				Name: activityTitle(a, checkIn),
+				Desc: a.Notes,
+				Type: a.ActivityType,
+			}
+			// GPX times are UTC, so points in cities without a known timezone go untimed.
+			if start, ok := utcTime(a.StartTime, day.Location); ok {
+				point.Time = start.Format(time.RFC3339)
+			}
+			doc.Waypoints = append(doc.Waypoints, point)
+			route.Points = append(route.Points, point)
+		}
+		if len(route.Points) > 0 {
+			doc.Routes = append(doc.Routes, route)
+		}
+	}
+	return doc
+}
+
+// ExportItineraryKML writes one folder per day holding a placemark per located activity
+// and a line through them in order_number order.
+func (s *TripPlanningService) ExportItineraryKML(tripID int) ([]byte, error) {
+	export, err := s.loadTripExport(tripID)
+	if err != nil {
+		return nil, err
+	}
+	return marshalXML(buildKML(export))
+}
+
+// buildKML lays out the KML document of a loaded trip.
+func buildKML(export *tripExport) kmlDocument {
+	doc := kmlDocument{
+		Xmlns: "http://www.opengis.net/kml/2.2",
+		Name:  export.Trip.Title,
+	}
+
+	seenHotels := make(map[int64]bool)
+	for _, day := range export.Days {
+		folder := kmlFolder{Name: dayLabel(day)}
+		var path []string
+		for _, a := range day.Activities {
+			checkIn := a.HotelID.Valid && !seenHotels[a.HotelID.Int64]
+			if a.HotelID.Valid {
+				seenHotels[a.HotelID.Int64] = true
+			}
+			if !hasLocation(a) {
+				continue
+			}
+
+			coords := kmlCoordinates(a)
+			path = append(path, coords)
+			placemark := kmlPlacemark{
+				Name:        activityTitle(a, checkIn),
+				Description: a.Notes,
+				Point:       &kmlGeometry{Coordinates: coords},
+			}
+			start, ok := utcTime(a.StartTime, day.Location)
+			end, _ := utcTime(a.EndTime, day.Location)
+			if ok {
+				placemark.TimeSpan = &kmlTimeSpan{Begin: start.Format(time.RFC3339), End: end.Format(time.RFC3339)}
+			}
+			folder.Placemarks = append(folder.Placemarks, placemark)
+		}
+		if len(path) > 1 {
+			folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+				Name:       fmt.Sprintf("Day %d route", day.Day.DayNumber),
+				LineString: &kmlGeometry{Coordinates: strings.Join(path, " ")},
+			})
+		}
+		doc.Folders = append(doc.Folders, folder)
+	}
+	return doc
+}
+
+func dayLabel(day exportDay) string {
+	label := fmt.Sprintf("Day %d · %s", day.Day.DayNumber, day.Day.Date.Format("Mon 2 Jan 2006"))
+	if day.City.Name != "" {
+		label += " · " + day.City.Name
+	}
+	return label
+}
+
+// kmlCoordinates is KML's lon,lat order.
+func kmlCoordinates(a *models.ItineraryActivity) string {
+	return fmt.Sprintf("%.6f,%.6f", a.EntityLongitude, a.EntityLatitude)
+}
+
+func marshalXML(v interface{}) ([]byte, error) {
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export: %w", err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
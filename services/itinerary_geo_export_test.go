@@ -0,0 +1,148 @@
+package services
+
+import (
+	"encoding/xml"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"travel-planning/models"
+)
+
+func TestBuildGPX(t *testing.T) {
+	now := time.Date(2026, time.May, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	doc := buildGPX(testTripExport(), now)
+
+	if doc.Name != "Paris, then Lyon" || doc.Time != "2026-05-01T12:00:00Z" {
+		t.Errorf("metadata = %q at %q", doc.Name, doc.Time)
+	}
+
+	tests := []struct {
+		name   string
+		number int
+		points []string
+	}{
+		{"Day 1 · Mon 1 Jun 2026 · Paris", 1, []string{
+			"Hotel check-in: Hotel Lutetia|hotel|48.851,2.327|2026-06-01T07:00:00Z",
+			"Louvre|attraction|48.8606,2.3376|2026-06-01T08:20:00Z",
+		}},
+		{"Day 2 · Tue 2 Jun 2026 · Lyon", 2, []string{
+			"Hotel: Hotel Lutetia|hotel|45.764,4.8357|",
+		}},
+	}
+	if len(doc.Routes) != len(tests) {
+		t.Fatalf("got %d routes, want %d", len(doc.Routes), len(tests))
+	}
+	var waypoints []string
+	for i, tt := range tests {
+		route := doc.Routes[i]
+		if route.Name != tt.name || route.Number != tt.number {
+			t.Errorf("route %d = %q #%d, want %q #%d", i, route.Name, route.Number, tt.name, tt.number)
+		}
+		if got := gpxPoints(route.Points); !slices.Equal(got, tt.points) {
+			t.Errorf("route %d points = %q, want %q", i, got, tt.points)
+		}
+		waypoints = append(waypoints, tt.points...)
+	}
+	if got := gpxPoints(doc.Waypoints); !slices.Equal(got, waypoints) {
+		t.Errorf("waypoints = %q, want %q", got, waypoints)
+	}
+}
+
+func gpxPoints(points []gpxWaypoint) []string {
+	var out []string
+	for _, p := range points {
+		coords := strconv.FormatFloat(p.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.Lon, 'f', -1, 64)
+		out = append(out, strings.Join([]string{p.Name, p.Type, coords, p.Time}, "|"))
+	}
+	return out
+}
+
+func TestBuildKML(t *testing.T) {
+	doc := buildKML(testTripExport())
+	if doc.Name != "Paris, then Lyon" {
+		t.Errorf("document name = %q", doc.Name)
+	}
+
+	tests := []struct {
+		name       string
+		placemarks []string
+	}{
+		{"Day 1 · Mon 1 Jun 2026 · Paris", []string{
+			"Hotel check-in: Hotel Lutetia|point 2.327000,48.851000|2026-06-01T07:00:00Z/2026-06-01T08:00:00Z",
+			"Louvre|point 2.337600,48.860600|2026-06-01T08:20:00Z/2026-06-01T10:50:00Z",
+			"Day 1 route|line 2.327000,48.851000 2.337600,48.860600|",
+		}},
+		{"Day 2 · Tue 2 Jun 2026 · Lyon", []string{
+			"Hotel: Hotel Lutetia|point 4.835700,45.764000|",
+		}},
+	}
+	if len(doc.Folders) != len(tests) {
+		t.Fatalf("got %d folders, want %d", len(doc.Folders), len(tests))
+	}
+	for i, tt := range tests {
+		folder := doc.Folders[i]
+		if folder.Name != tt.name {
+			t.Errorf("folder %d = %q, want %q", i, folder.Name, tt.name)
+		}
+		var got []string
+		for _, p := range folder.Placemarks {
+			geometry := ""
+			if p.Point != nil {
+				geometry = "point " + p.Point.Coordinates
+			}
+			if p.LineString != nil {
+				geometry = "line " + p.LineString.Coordinates
+			}
+			span := ""
+			if p.TimeSpan != nil {
+				span = p.TimeSpan.Begin + "/" + p.TimeSpan.End
+			}
+			got = append(got, p.Name+"|"+geometry+"|"+span)
+		}
+		if !slices.Equal(got, tt.placemarks) {
+			t.Errorf("folder %d placemarks = %q, want %q", i, got, tt.placemarks)
+		}
+	}
+}
+
+func TestBuildGeoExportsWithoutLocations(t *testing.T) {
+	export := &tripExport{
+		Trip: &models.Trip{Title: "Flights only"},
+		Days: []exportDay{{
+			Day:        &models.TripItinerary{DayNumber: 1, Date: time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)},
+			City:       &models.City{},
+			Activities: []*models.ItineraryActivity{{ActivityType: "flight", EntityName: "AF 123"}},
+		}},
+	}
+
+	if gpx := buildGPX(export, time.Now()); len(gpx.Waypoints) != 0 || len(gpx.Routes) != 0 {
+		t.Errorf("GPX has %d waypoints and %d routes, want none", len(gpx.Waypoints), len(gpx.Routes))
+	}
+	kml := buildKML(export)
+	if len(kml.Folders) != 1 || len(kml.Folders[0].Placemarks) != 0 {
+		t.Fatalf("KML folders = %+v, want one empty folder", kml.Folders)
+	}
+	if kml.Folders[0].Name != "Day 1 · Mon 1 Jun 2026" {
+		t.Errorf("folder name = %q", kml.Folders[0].Name)
+	}
+}
+
+func TestMarshalXML(t *testing.T) {
+	out, err := marshalXML(buildKML(testTripExport()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(out), xml.Header) {
+		t.Errorf("missing XML header: %.40q", out)
+	}
+
+	var decoded kmlDocument
+	if err := xml.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("output does not parse back: %v", err)
+	}
+	if decoded.Xmlns != "http://www.opengis.net/kml/2.2" || len(decoded.Folders) != 2 {
+		t.Errorf("decoded = %q with %d folders", decoded.Xmlns, len(decoded.Folders))
+	}
+}
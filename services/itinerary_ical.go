@@ -0,0 +1,119 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// icsFloatingFormat is a local time that is not bound to any timezone (RFC 5545, 3.3.5).
+const icsFloatingFormat = "20060102T150405"
+
+// ExportItineraryICS renders every activity of a trip as an iCalendar (RFC 5545) VEVENT.
+// Stored times are wall-clock times at the destination, so they are read in the timezone
+// of each day's city and written as UTC; cities without a known timezone get floating times.
+func (s *TripPlanningService) ExportItineraryICS(tripID int) ([]byte, error) {
+	export, err := s.loadTripExport(tripID)
+	if err != nil {
+		return nil, err
+	}
+	return renderICS(export, time.Now()), nil
+}
+
+// renderICS writes the calendar of a loaded trip; now is the DTSTAMP of every event.
+func renderICS(export *tripExport, now time.Time) []byte {
+	w := &icsWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//travel-planning//itinerary//EN")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", icsEscape(export.Trip.Title))
+	if len(export.Days) > 0 && export.Days[0].Location != nil {
+		w.line("X-WR-TIMEZONE", export.Days[0].Location.String())
+	}
+
+	stamp := now.UTC().Format(icsTimeFormat)
+	seenHotels := make(map[int64]bool)
+	for _, day := range export.Days {
+		for _, a := range day.Activities {
+			checkIn := a.HotelID.Valid && !seenHotels[a.HotelID.Int64]
+			if a.HotelID.Valid {
+				seenHotels[a.HotelID.Int64] = true
+			}
+
+			w.line("BEGIN", "VEVENT")
+			w.line("UID", fmt.Sprintf("activity-%d@travel-planning", a.ActivityID))
+			w.line("DTSTAMP", stamp)
+			w.line("DTSTART", icsTime(a.StartTime, day.Location))
+			w.line("DTEND", icsTime(a.EndTime, day.Location))
+			w.line("SUMMARY", icsEscape(activityTitle(a, checkIn)))
+			w.line("CATEGORIES", strings.ToUpper(a.ActivityType))
+
+			description := a.Notes
+			if a.EntityDetail != "" && a.ActivityType != "flight" {
+				description = strings.TrimSpace(a.EntityDetail + "\n" + description)
+			}
+			if description != "" {
+				w.line("DESCRIPTION", icsEscape(description))
+			}
+
+			if hasLocation(a) {
+				coords := fmt.Sprintf("%.6f,%.6f", a.EntityLatitude, a.EntityLongitude)
+				location := coords
+				if a.EntityName != "" {
+					location = a.EntityName + " (" + coords + ")"
+				}
+				w.line("LOCATION", icsEscape(location))
+				w.line("GEO", fmt.Sprintf("%.6f;%.6f", a.EntityLatitude, a.EntityLongitude))
+			} else if day.City.Name != "" {
+				w.line("LOCATION", icsEscape(day.City.Name))
+			}
+			w.line("END", "VEVENT")
+		}
+	}
+	w.line("END", "VCALENDAR")
+
+	return w.buf.Bytes()
+}
+
+func icsTime(t time.Time, loc *time.Location) string {
+	if utc, ok := utcTime(t, loc); ok {
+		return utc.Format(icsTimeFormat)
+	}
+	return t.Format(icsFloatingFormat)
+}
+
+// icsWriter writes content lines with CRLF endings, folded at 75 octets.
+type icsWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *icsWriter) line(name, value string) {
+	content := name + ":" + value
+	limit := 75
+	for len(content) > limit {
+		// Never split a multi-byte character across lines.
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = 74
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
+
+var icsReplacer = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icsEscape(s string) string {
+	return icsReplacer.Replace(s)
+}
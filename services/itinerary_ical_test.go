@@ -0,0 +1,184 @@
+package services
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"travel-planning/models"
+	"unicode/utf8"
+)
+
+// testTripExport is a two-day trip: Paris with a known UTC offset, then Lyon without a timezone.
+func testTripExport() *tripExport {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.June, day, hour, minute, 0, 0, time.UTC)
+	}
+	hotel := sql.NullInt64{Int64: 7, Valid: true}
+
+	return &tripExport{
+		Trip: &models.Trip{TripID: 1, Title: "Paris, then Lyon"},
+		Days: []exportDay{
+			{
+				Day:      &models.TripItinerary{DayNumber: 1, Date: at(1, 0, 0)},
+				City:     &models.City{Name: "Paris"},
+				Location: time.FixedZone("CEST", 2*60*60),
+				Activities: []*models.ItineraryActivity{
+					{ActivityID: 1, ActivityType: "hotel", HotelID: hotel, EntityName: "Hotel Lutetia",
+						EntityLatitude: 48.851, EntityLongitude: 2.327, StartTime: at(1, 9, 0), EndTime: at(1, 10, 0)},
+					{ActivityID: 2, ActivityType: "attraction", EntityName: "Louvre", EntityDetail: "museum", Notes: "Book ahead",
+						EntityLatitude: 48.8606, EntityLongitude: 2.3376, StartTime: at(1, 10, 20), EndTime: at(1, 12, 50)},
+					{ActivityID: 3, ActivityType: "flight", EntityName: "AF 123", EntityDetail: "CDG-LYS",
+						StartTime: at(1, 18, 0), EndTime: at(1, 19, 30)},
+				},
+			},
+			{
+				Day:  &models.TripItinerary{DayNumber: 2, Date: at(2, 0, 0)},
+				City: &models.City{Name: "Lyon"},
+				Activities: []*models.ItineraryActivity{
+					{ActivityID: 4, ActivityType: "hotel", HotelID: hotel, EntityName: "Hotel Lutetia",
+						EntityLatitude: 45.764, EntityLongitude: 4.8357, StartTime: at(2, 9, 0), EndTime: at(2, 10, 0)},
+					{ActivityID: 5, ActivityType: "restaurant", EntityName: "Chez Paul",
+						StartTime: at(2, 19, 0), EndTime: at(2, 20, 30)},
+				},
+			},
+		},
+	}
+}
+
+func TestICSWriterLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		lines int
+	}{
+		{"short", "Louvre", 1},
+		{"exactly 75 octets", strings.Repeat("a", 75-len("SUMMARY:")), 1},
+		{"folded", strings.Repeat("a", 200), 3},
+		{"multi-byte characters stay whole", strings.Repeat("é", 100), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &icsWriter{}
+			w.line("SUMMARY", tt.value)
+			out := w.buf.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line does not end with CRLF: %q", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(lines) != tt.lines {
+				t.Errorf("got %d lines, want %d", len(lines), tt.lines)
+			}
+			for i, l := range lines {
+				if len(l) > 75 {
+					t.Errorf("line %d is %d octets long", i, len(l))
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line %d splits a character: %q", i, l)
+				}
+				if i > 0 && !strings.HasPrefix(l, " ") {
+					t.Errorf("continuation line %d does not start with a space", i)
+				}
+			}
+			if got := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", ""); got != "SUMMARY:"+tt.value {
+				t.Errorf("unfolded line = %q", got)
+			}
+		})
+	}
+}
+
+func TestICSEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Louvre", "Louvre"},
+		{"Paris, France", `Paris\, France`},
+		{"a;b", `a\;b`},
+		{`C:\tmp`, `C:\\tmp`},
+		{"line one\nline two\r\nline three", `line one\nline two\nline three`},
+	}
+	for _, tt := range tests {
+		if got := icsEscape(tt.in); got != tt.want {
+			t.Errorf("icsEscape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestICSTime(t *testing.T) {
+	stored := time.Date(2026, time.June, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want string
+	}{
+		{"known timezone is written as UTC", time.FixedZone("CEST", 2*60*60), "20260601T073000Z"},
+		{"UTC city", time.UTC, "20260601T093000Z"},
+		{"unknown timezone is floating", nil, "20260601T093000"},
+	}
+	for _, tt := range tests {
+		if got := icsTime(stored, tt.loc); got != tt.want {
+			t.Errorf("%s: icsTime = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRenderICS(t *testing.T) {
+	now := time.Date(2026, time.May, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	out := string(renderICS(testTripExport(), now))
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	blocks := strings.Split(unfolded, "BEGIN:VEVENT\r\n")
+
+	header := strings.Split(blocks[0], "\r\n")
+	for _, want := range []string{"BEGIN:VCALENDAR", "VERSION:2.0", `X-WR-CALNAME:Paris\, then Lyon`, "X-WR-TIMEZONE:CEST"} {
+		if !slices.Contains(header, want) {
+			t.Errorf("calendar header misses %q", want)
+		}
+	}
+	if !strings.HasSuffix(out, "END:VCALENDAR\r\n") {
+		t.Error("calendar is not closed")
+	}
+
+	tests := []struct {
+		name   string
+		want   []string
+		absent string
+	}{
+		{"hotel check-in", []string{
+			"UID:activity-1@travel-planning", "DTSTAMP:20260501T120000Z",
+			"DTSTART:20260601T070000Z", "DTEND:20260601T080000Z",
+			"SUMMARY:Hotel check-in: Hotel Lutetia", "CATEGORIES:HOTEL",
+			`LOCATION:Hotel Lutetia (48.851000\,2.327000)`, "GEO:48.851000;2.327000",
+		}, "DESCRIPTION"},
+		{"attraction", []string{
+			"UID:activity-2@travel-planning", "SUMMARY:Louvre", `DESCRIPTION:museum\nBook ahead`,
+		}, ""},
+		{"flight has no entity description", []string{
+			"UID:activity-3@travel-planning", "SUMMARY:Flight: AF 123", "CATEGORIES:FLIGHT", "LOCATION:Paris",
+		}, "DESCRIPTION"},
+		{"hotel without timezone", []string{
+			"UID:activity-4@travel-planning", "DTSTART:20260602T090000", "DTEND:20260602T100000",
+			"SUMMARY:Hotel: Hotel Lutetia",
+		}, ""},
+		{"dinner without coordinates", []string{
+			"UID:activity-5@travel-planning", "SUMMARY:Dinner: Chez Paul", "LOCATION:Lyon", "END:VEVENT",
+		}, "GEO"},
+	}
+	if got := len(blocks) - 1; got != len(tests) {
+		t.Fatalf("got %d events, want %d", got, len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(blocks[i+1], "\r\n")
+			for _, want := range tt.want {
+				if !slices.Contains(lines, want) {
+					t.Errorf("event misses %q in %q", want, lines)
+				}
+			}
+			if tt.absent != "" && slices.ContainsFunc(lines, func(l string) bool { return strings.HasPrefix(l, tt.absent) }) {
+				t.Errorf("event has an unexpected %s line", tt.absent)
+			}
+		})
+	}
+}
@@ -4,34 +4,48 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
+	"travel-planning/models"
+	"travel-planning/repository"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
 type CustomClaims struct {
-	UserID int `json:"user_id"`
+	UserID int    `json:"user_id"`
+	Role   string `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// userRoleRank orders the account roles; a higher role passes every check of a lower one.
+var userRoleRank = map[string]int{
+	models.UserRoleUser:  1,
+	models.UserRoleAdmin: 2,
+}
+
 type JWTService struct {
 	secretKey []byte
 	expiry    time.Duration
+	userRepo  *repository.UserRepository
 }
 
-func NewJWTService(jwtSecret string) *JWTService {
+func NewJWTService(jwtSecret string, userRepo *repository.UserRepository) *JWTService {
 	return &JWTService{
 		secretKey: []byte(jwtSecret),
 		expiry:    24 * time.Hour,
+		userRepo:  userRepo,
 	}
 }
 
-func (s *JWTService) GenerateToken(userID int) (string, error) {
-	slog.Debug("Generating new access token", "user_id", userID)
+// GenerateToken issues an access token carrying the user's account role.
+func (s *JWTService) GenerateToken(userID int, role string) (string, error) {
+	slog.Debug("Generating new access token", "user_id", userID, "role", role)
 
 	claims := CustomClaims{
 		UserID: userID,
+		Role:   role,
 
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   fmt.Sprintf("%d", userID),
@@ -105,8 +119,63 @@ func (s *JWTService) AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		slog.Debug("User authenticated via JWT", "user_id", claims.UserID, "path", r.URL.Path)
+		// Tokens issued before roles existed carry none and act as plain users.
+		role := claims.Role
+		if role == "" {
+			role = models.UserRoleUser
+		}
+
+		slog.Debug("User authenticated via JWT", "user_id", claims.UserID, "role", role, "path", r.URL.Path)
 		r.Header.Set("X-User-ID", fmt.Sprintf("%d", claims.UserID))
+		r.Header.Set("X-User-Role", role)
+		next(w, r)
+	}
+}
+
+// StreamAuthMiddleware is AuthMiddleware for Server-Sent Events routes. A browser EventSource
+// cannot set the Authorization header, so the token may come as the access_token query
+// parameter instead; the header still wins when both are present.
+func (s *JWTService) StreamAuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	auth := s.AuthMiddleware(next)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			if token := r.URL.Query().Get("access_token"); token != "" {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		auth(w, r)
+	}
+}
+
+// RequireRole guards a route wrapped in AuthMiddleware: the wrapped handler only runs for
+// users holding at least the required account role. The role is read from the database on
+// every request, so a revoked admin loses access at once rather than when the token expires.
+func (s *JWTService) RequireRole(required string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := strconv.Atoi(r.Header.Get("X-User-ID"))
+		if err != nil || userID <= 0 {
+			http.Error(w, "Authentication error", http.StatusUnauthorized)
+			return
+		}
+
+		role, err := s.userRepo.GetRole(userID)
+		if err != nil {
+			slog.Error("Failed to check user role", "user_id", userID, "error", err)
+			http.Error(w, "Authorization error", http.StatusInternalServerError)
+			return
+		}
+		if role == "" {
+			slog.Warn("Access denied: user no longer exists", "user_id", userID, "path", r.URL.Path)
+			http.Error(w, "Authentication error", http.StatusUnauthorized)
+			return
+		}
+		if userRoleRank[role] < userRoleRank[required] {
+			slog.Warn("Access denied: insufficient role", "user_id", userID, "role", role, "required", required, "path", r.URL.Path)
+			http.Error(w, fmt.Sprintf("This action requires the %s role", required), http.StatusForbidden)
+			return
+		}
+
+		r.Header.Set("X-User-Role", role)
 		next(w, r)
 	}
 }
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"travel-planning/models"
+)
+
+// ErrInvalidSearch is returned for empty queries and unknown place types.
+var ErrInvalidSearch = errors.New("invalid search")
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	// nearRadiusKm bounds "X near <landmark>" searches; "X in <city>" uses the city instead.
+	nearRadiusKm = 2.0
+)
+
+// nearPattern splits "sushi near Shibuya" into what and where, at the last connector.
+var nearPattern = regexp.MustCompile(`(?i)^(.+)\s+(?:near|around|in)\s+(.+)$`)
+
+// SearchPlaces runs a ranked full-text and fuzzy search over cities, attractions, hotels
+// and restaurants. A trailing "near/around/in <place>" narrows results to that city, or to
+// a radius around that landmark, when the place resolves; otherwise the whole text is searched.
+func (s *ResourceService) SearchPlaces(text string, types []string, limit int) (*models.SearchResponse, error) {
+	text = strings.Join(strings.Fields(text), " ")
+	if len([]rune(text)) < 2 {
+		return nil, fmt.Errorf("%w: query must be at least 2 characters", ErrInvalidSearch)
+	}
+	if len(types) == 0 {
+		types = models.PlaceTypes
+	}
+	for _, t := range types {
+		if !slices.Contains(models.PlaceTypes, t) {
+			return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidSearch, t)
+		}
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	resp := &models.SearchResponse{Query: text, Terms: text}
+	q := models.SearchQuery{Text: text, Types: types, Limit: limit}
+
+	if m := nearPattern.FindStringSubmatch(text); m != nil {
+		anchor, err := s.SearchRepo.ResolvePlace(m[2])
+		if err != nil {
+			return nil, err
+		}
+		if anchor != nil {
+			resp.Terms, resp.Near = m[1], anchor
+			q.Text = m[1]
+			if anchor.EntityType == models.PlaceCity {
+				q.CityID = anchor.EntityID
+			} else {
+				q.Latitude, q.Longitude, q.RadiusKm = *anchor.Latitude, *anchor.Longitude, nearRadiusKm
+			}
+		}
+	}
+
+	results, err := s.SearchRepo.Search(q)
+	if err != nil {
+		return nil, err
+	}
+	resp.Results = results
+	return resp, nil
+}
+
+const (
+	defaultNearbyRadiusKm = 1.0
+	maxNearbyRadiusKm     = 50.0
+	defaultNearbyLimit    = 50
+	maxNearbyLimit        = 200
+)
+
+// nearbyTypes are the place types with coordinates worth walking to.
+var nearbyTypes = []string{models.PlaceAttraction, models.PlaceHotel, models.PlaceRestaurant}
+
+// NearbyPlaces returns attractions, hotels and restaurants within radius of a point, closest first.
+func (s *ResourceService) NearbyPlaces(q models.NearbyQuery) ([]models.NearbyPlace, error) {
+	if q.Latitude < -90 || q.Latitude > 90 || q.Longitude < -180 || q.Longitude > 180 {
+		return nil, fmt.Errorf("%w: coordinates out of range", ErrInvalidSearch)
+	}
+	if q.RadiusKm == 0 {
+		q.RadiusKm = defaultNearbyRadiusKm
+	}
+	if q.RadiusKm < 0 || q.RadiusKm > maxNearbyRadiusKm {
+		return nil, fmt.Errorf("%w: radius_km must be between 0 and %g", ErrInvalidSearch, maxNearbyRadiusKm)
+	}
+	if len(q.Types) == 0 {
+		q.Types = nearbyTypes
+	}
+	for _, t := range q.Types {
+		if !slices.Contains(nearbyTypes, t) {
+			return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidSearch, t)
+		}
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultNearbyLimit
+	}
+	q.Limit = min(q.Limit, maxNearbyLimit)
+
+	return s.SearchRepo.Nearby(q)
+}
@@ -0,0 +1,38 @@
+package services
+
+import "testing"
+
+func TestNearPattern(t *testing.T) {
+	tests := []struct {
+		text      string
+		wantWhat  string
+		wantWhere string
+		wantMatch bool
+	}{
+		{"sushi near Shibuya", "sushi", "Shibuya", true},
+		{"museums in Paris", "museums", "Paris", true},
+		{"cafes around Eiffel Tower", "cafes", "Eiffel Tower", true},
+		{"Ramen NEAR Tokyo Tower", "Ramen", "Tokyo Tower", true},
+		{"bars in old town near the river", "bars in old town", "the river", true},
+		{"Inn in Kyoto", "Inn", "Kyoto", true},
+		{"Golden Inn", "", "", false},
+		{"Indian restaurants", "", "", false},
+		{"near Shibuya", "", "", false},
+		{"pizza in", "", "", false},
+		{"Louvre", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			m := nearPattern.FindStringSubmatch(tt.text)
+			if (m != nil) != tt.wantMatch {
+				t.Fatalf("match = %v, want %v", m != nil, tt.wantMatch)
+			}
+			if m == nil {
+				return
+			}
+			if m[1] != tt.wantWhat || m[2] != tt.wantWhere {
+				t.Errorf("split = (%q, %q), want (%q, %q)", m[1], m[2], tt.wantWhat, tt.wantWhere)
+			}
+		})
+	}
+}
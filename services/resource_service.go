@@ -1,12 +1,16 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"travel-planning/models"
 	"travel-planning/repository"
 )
 
+// ErrInvalidListQuery is returned for unknown sort keys, foreign cursors and bad limits.
+var ErrInvalidListQuery = repository.ErrInvalidListQuery
+
 type ResourceService struct {
 	HotelRepo      *repository.HotelRepository
 	CityRepo       *repository.CityRepository
@@ -14,6 +18,7 @@ type ResourceService struct {
 	CountryRepo    *repository.CountryRepository
 	RestaurantRepo *repository.RestaurantRepository
 	FlightRepo     *repository.FlightRepository
+	SearchRepo     *repository.SearchRepository
 }
 
 func NewResourceService(
@@ -23,6 +28,7 @@ func NewResourceService(
 	CountryRepo *repository.CountryRepository,
 	RestaurantRepo *repository.RestaurantRepository,
 	FlightRepo *repository.FlightRepository,
+	SearchRepo *repository.SearchRepository,
 ) *ResourceService {
 	return &ResourceService{
 		HotelRepo:      HotelRepo,
@@ -31,6 +37,7 @@ func NewResourceService(
 		CountryRepo:    CountryRepo,
 		RestaurantRepo: RestaurantRepo,
 		FlightRepo:     FlightRepo,
+		SearchRepo:     SearchRepo,
 	}
 }
 
@@ -44,54 +51,64 @@ func (s *ResourceService) GetAllCountries() ([]models.Country, error) {
 	return countries, nil
 }
 
-func (s *ResourceService) GetAllCities() ([]models.City, error) {
-	cities, err := s.CityRepo.GetAllCities()
+func (s *ResourceService) GetAllCities(filter models.ResourceFilter) (models.Page[models.City], error) {
+	page, err := s.CityRepo.GetAllCities(filter)
 	if err != nil {
-		slog.Error("Database error: failed to fetch cities", "error", err)
-		return nil, err
+		if !errors.Is(err, ErrInvalidListQuery) {
+			slog.Error("Database error: failed to fetch cities", "error", err)
+		}
+		return page, err
 	}
-	slog.Debug("Fetched cities from database", "count", len(cities))
-	return cities, nil
+	slog.Debug("Fetched cities from database", "count", len(page.Data), "has_more", page.HasMore)
+	return page, nil
 }
 
-func (s *ResourceService) GetAllAttractions() ([]models.Attraction, error) {
-	attractions, err := s.AttractionRepo.GetAllAttractions()
+func (s *ResourceService) GetAllAttractions(filter models.ResourceFilter) (models.Page[models.Attraction], error) {
+	page, err := s.AttractionRepo.GetAllAttractions(filter)
 	if err != nil {
-		slog.Error("Database error: failed to fetch attractions", "error", err)
-		return nil, err
+		if !errors.Is(err, ErrInvalidListQuery) {
+			slog.Error("Database error: failed to fetch attractions", "error", err)
+		}
+		return page, err
 	}
-	slog.Debug("Fetched attractions from database", "count", len(attractions))
-	return attractions, nil
+	slog.Debug("Fetched attractions from database", "count", len(page.Data), "has_more", page.HasMore)
+	return page, nil
 }
 
-func (s *ResourceService) GetAllHotels() ([]models.Hotel, error) {
-	hotels, err := s.HotelRepo.GetAllHotels()
+func (s *ResourceService) GetAllHotels(filter models.ResourceFilter) (models.Page[models.Hotel], error) {
+	page, err := s.HotelRepo.GetAllHotels(filter)
 	if err != nil {
-		slog.Error("Database error: failed to fetch hotels", "error", err)
-		return nil, err
+		if !errors.Is(err, ErrInvalidListQuery) {
+			slog.Error("Database error: failed to fetch hotels", "error", err)
+		}
+		return page, err
 	}
-	slog.Debug("Fetched hotels from database", "count", len(hotels))
-	return hotels, nil
+	slog.Debug("Fetched hotels from database", "count", len(page.Data), "has_more", page.HasMore)
+	return page, nil
 }
 
-func (s *ResourceService) GetAllRestaurants() ([]models.Restaurant, error) {
-	restaurants, err := s.RestaurantRepo.GetAllRestaurants()
+func (s *ResourceService) GetAllRestaurants(filter models.ResourceFilter) (models.Page[models.Restaurant], error) {
+	page, err := s.RestaurantRepo.GetAllRestaurants(filter)
 	if err != nil {
-		slog.Error("Database error: failed to fetch restaurants", "error", err)
-		return nil, err
+		if !errors.Is(err, ErrInvalidListQuery) {
+			slog.Error("Database error: failed to fetch restaurants", "error", err)
+		}
+		return page, err
 	}
-	slog.Debug("Fetched restaurants from database", "count", len(restaurants))
-	return restaurants, nil
+	slog.Debug("Fetched restaurants from database", "count", len(page.Data), "has_more", page.HasMore)
+	return page, nil
 }
 
-func (s *ResourceService) GetAllFlights() ([]models.Flight, error) {
-	flights, err := s.FlightRepo.GetAllFlights()
+func (s *ResourceService) GetAllFlights(filter models.ResourceFilter) (models.Page[models.Flight], error) {
+	page, err := s.FlightRepo.GetAllFlights(filter)
 	if err != nil {
-		slog.Error("Database error: failed to fetch flights", "error", err)
-		return nil, err
+		if !errors.Is(err, ErrInvalidListQuery) {
+			slog.Error("Database error: failed to fetch flights", "error", err)
+		}
+		return page, err
 	}
-	slog.Debug("Fetched flights from database", "count", len(flights))
-	return flights, nil
+	slog.Debug("Fetched flights from database", "count", len(page.Data), "has_more", page.HasMore)
+	return page, nil
 }
 
 func (s *ResourceService) GetVisitedEntities(userID int, entityType string) (interface{}, error) {
@@ -158,10 +158,6 @@ func (s *RestaurantAPIService) FetchRestaurantsByCity(cityID int, lat, lon float
 	return restaurants, nil
 }
 
-func (s *TripPlanningService) DeleteUserTrip(tripID, userID int) error {
-	return s.TripRepo.DeleteByIDAndUserID(tripID, userID)
-}
-
 func (s *RestaurantAPIService) GetVisitedRestaurants(userID int) ([]models.Restaurant, error) {
 	return s.RestaurantRepo.GetVisitedRestaurants(userID)
 }
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"travel-planning/models"
+	"travel-planning/repository"
+)
+
+var (
+	ErrReviewNotFound  = repository.ErrReviewNotFound
+	ErrDuplicateReview = repository.ErrDuplicateReview
+)
+
+// Flags raised by the automatic review checks. A flagged review waits in the moderation
+// queue as pending until an admin approves or rejects it.
+const (
+	FlagProfanity = "profanity"
+	FlagLinkSpam  = "link_spam"
+	FlagTooLong   = "too_long"
+)
+
+const maxReviewLength = 2000
+
+// profanity is matched on whole words, case-insensitively.
+var profanity = regexp.MustCompile(`(?i)\b(fuck\w*|shit\w*|bitch\w*|bastard\w*|asshole\w*|cunt\w*|dick|dickhead|motherfuck\w*|wank\w*|twat\w*|bollocks|prick)\b`)
+
+// links catches URLs, bare www. hosts and common link shorteners.
+var links = regexp.MustCompile(`(?i)(https?://|www\.|\b(bit\.ly|tinyurl\.com|t\.co|goo\.gl)/)`)
+
+// checkReview runs the automatic moderation rules over a review comment.
+func checkReview(comment string) []string {
+	flags := []string{}
+	if profanity.MatchString(comment) {
+		flags = append(flags, FlagProfanity)
+	}
+	if links.MatchString(comment) {
+		flags = append(flags, FlagLinkSpam)
+	}
+	if len([]rune(comment)) > maxReviewLength {
+		flags = append(flags, FlagTooLong)
+	}
+	return flags
+}
+
+// reviewStatus is approved for clean reviews and pending for flagged ones.
+func reviewStatus(flags []string) string {
+	if len(flags) > 0 {
+		return models.ReviewPending
+	}
+	return models.ReviewApproved
+}
+
+// GetModerationQueue pages through reviews in one moderation state, pending by default.
+func (s *ReviewService) GetModerationQueue(status string, params models.ListParams) (models.Page[models.Review], error) {
+	if status == "" {
+		status = models.ReviewPending
+	}
+	switch status {
+	case models.ReviewPending, models.ReviewApproved, models.ReviewRejected:
+	default:
+		return models.Page[models.Review]{}, fmt.Errorf("%w: unknown status %q", ErrInvalidListQuery, status)
+	}
+	return s.ReviewRepo.GetByStatus(status, params)
+}
+
+// ModerateReview approves or rejects a review. Either decision also overrides the
+// automatic flags of a review that was published without any.
+func (s *ReviewService) ModerateReview(moderatorID, reviewID int, approve bool, note string) error {
+	status := models.ReviewRejected
+	if approve {
+		status = models.ReviewApproved
+	}
+	if err := s.ReviewRepo.SetStatus(reviewID, status, moderatorID, strings.TrimSpace(note)); err != nil {
+		return err
+	}
+	slog.Info("Review moderated", "review_id", reviewID, "moderator_id", moderatorID, "status", status)
+	return nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"travel-planning/models"
+)
+
+func TestCheckReview(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    []string
+	}{
+		{"clean", "Lovely view from the top, worth the queue.", []string{}},
+		{"empty", "", []string{}},
+		{"profanity", "The guide was a total prick.", []string{FlagProfanity}},
+		{"profanity any case", "SHITTY service", []string{FlagProfanity}},
+		{"no profanity inside words", "Took a dickens novel to Scunthorpe, met a pricklepear seller", []string{}},
+		{"url", "Book at https://example.com instead", []string{FlagLinkSpam}},
+		{"bare www host", "see WWW.example.com", []string{FlagLinkSpam}},
+		{"shortener", "details: bit.ly/abc", []string{FlagLinkSpam}},
+		{"at the length limit", strings.Repeat("a", maxReviewLength), []string{}},
+		{"too long", strings.Repeat("a", maxReviewLength+1), []string{FlagTooLong}},
+		{"length counts characters", strings.Repeat("é", maxReviewLength), []string{}},
+		{
+			"every rule",
+			"fuck this, go to http://spam.example " + strings.Repeat("x", maxReviewLength),
+			[]string{FlagProfanity, FlagLinkSpam, FlagTooLong},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkReview(tt.comment); !slices.Equal(got, tt.want) {
+				t.Errorf("checkReview = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewStatus(t *testing.T) {
+	tests := []struct {
+		flags []string
+		want  string
+	}{
+		{nil, models.ReviewApproved},
+		{[]string{}, models.ReviewApproved},
+		{[]string{FlagLinkSpam}, models.ReviewPending},
+		{[]string{FlagProfanity, FlagTooLong}, models.ReviewPending},
+	}
+	for _, tt := range tests {
+		if got := reviewStatus(tt.flags); got != tt.want {
+			t.Errorf("reviewStatus(%v) = %q, want %q", tt.flags, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -8,33 +9,56 @@ import (
 	"travel-planning/repository"
 )
 
+var (
+	// ErrInvalidReview is returned for out-of-range ratings and unknown places.
+	ErrInvalidReview = errors.New("invalid review")
+	// ErrPlaceNotFound is returned when listing reviews of a place that does not exist.
+	ErrPlaceNotFound = errors.New("place not found")
+	// ErrPlaceNotVisited is returned when the place is not on any of the user's completed trips.
+	ErrPlaceNotVisited = errors.New("only places from your completed trips can be reviewed")
+)
+
 type ReviewService struct {
 	ReviewRepo *repository.ReviewRepository
+	UserRepo   *repository.UserRepository
 }
 
-func NewReviewService(reviewRepo *repository.ReviewRepository) *ReviewService {
+func NewReviewService(reviewRepo *repository.ReviewRepository, userRepo *repository.UserRepository) *ReviewService {
 	return &ReviewService{
 		ReviewRepo: reviewRepo,
+		UserRepo:   userRepo,
 	}
 }
 
-func (s *ReviewService) CreateReview(userID int, req models.CreateReviewRequest) (int, error) {
+// CreateReview stores the user's only review of a place. Reviews flagged by the
+// automatic checks are held as pending until a moderator decides on them.
+func (s *ReviewService) CreateReview(userID int, req models.CreateReviewRequest) (*models.Review, error) {
 	entityType := strings.ToLower(req.EntityType)
 	l := slog.With("user_id", userID, "entity_type", entityType, "entity_id", req.EntityID)
 
 	if req.Rating < 1 || req.Rating > 5 {
 		l.Warn("Review creation failed: invalid rating", "rating", req.Rating)
-		return 0, fmt.Errorf("rating must be >= 1 and  <= 5")
+		return nil, fmt.Errorf("%w: rating must be >= 1 and <= 5", ErrInvalidReview)
 	}
 
 	if entityType != "hotel" && entityType != "attraction" && entityType != "restaurant" {
 		l.Warn("Review creation failed: invalid entity type")
-		return 0, fmt.Errorf("invalid entity type")
+		return nil, fmt.Errorf("%w: invalid entity type", ErrInvalidReview)
 	}
 
 	if req.EntityID <= 0 {
 		l.Warn("Review creation failed: invalid entity ID")
-		return 0, fmt.Errorf("invalid entity ID")
+		return nil, fmt.Errorf("%w: invalid entity ID", ErrInvalidReview)
+	}
+
+	visited, err := s.ReviewRepo.HasVisited(userID, entityType, req.EntityID)
+	if err != nil {
+		l.Error("Database error: failed to check visit", "error", err)
+		return nil, fmt.Errorf("failed to check visit: %w", err)
+	}
+	if !visited {
+		l.Warn("Review creation failed: place not visited on a completed trip")
+		return nil, ErrPlaceNotVisited
 	}
 
 	newReview := &models.Review{
@@ -43,18 +67,80 @@ func (s *ReviewService) CreateReview(userID int, req models.CreateReviewRequest)
 		Comment:    req.Comment,
 		EntityType: entityType,
 		EntityID:   req.EntityID,
+		Verified:   true,
 	}
+	newReview.Flags = checkReview(newReview.Comment)
+	newReview.Status = reviewStatus(newReview.Flags)
 
 	l.Debug("Attempting to insert review into database")
 
 	reviewID, err := s.ReviewRepo.Insert(newReview)
+	if errors.Is(err, ErrDuplicateReview) {
+		l.Warn("Review creation failed: place already reviewed")
+		return nil, err
+	}
 	if err != nil {
 		l.Error("Database error: failed to save review", "error", err)
-		return 0, fmt.Errorf("failed to save review: %w", err)
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+	newReview.ReviewID = reviewID
+
+	l.Info("Review created successfully", "review_id", reviewID, "status", newReview.Status, "flags", newReview.Flags)
+	return newReview, nil
+}
+
+// UpdateReview changes the rating and comment of the user's review, keeping the previous
+// version in its history. The new text goes through the automatic checks again, and a
+// rejected review returns to the moderation queue rather than being published.
+func (s *ReviewService) UpdateReview(userID, reviewID int, req models.UpdateReviewRequest) (*models.Review, error) {
+	l := slog.With("user_id", userID, "review_id", reviewID)
+
+	if req.Rating < 1 || req.Rating > 5 {
+		l.Warn("Review update failed: invalid rating", "rating", req.Rating)
+		return nil, fmt.Errorf("%w: rating must be >= 1 and <= 5", ErrInvalidReview)
+	}
+
+	review := &models.Review{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Rating:   req.Rating,
+		Comment:  req.Comment,
+		Flags:    checkReview(req.Comment),
+	}
+	review.Status = reviewStatus(review.Flags)
+
+	if err := s.ReviewRepo.Update(review); err != nil {
+		if !errors.Is(err, ErrReviewNotFound) {
+			l.Error("Database error: failed to update review", "error", err)
+		}
+		return nil, err
 	}
 
-	l.Info("Review created successfully", "review_id", reviewID)
-	return reviewID, nil
+	l.Info("Review updated", "status", review.Status, "flags", review.Flags)
+	return review, nil
+}
+
+// GetReviewHistory returns the previous versions of a review to its author or an admin.
+// The admin role is read from the database, like RequireRole does, so a revoked admin
+// loses access before their token expires.
+func (s *ReviewService) GetReviewHistory(userID, reviewID int) ([]models.ReviewEdit, error) {
+	review, err := s.ReviewRepo.GetByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+	if review.UserID != userID {
+		role, err := s.UserRepo.GetRole(userID)
+		if err != nil {
+			return nil, err
+		}
+		if role != models.UserRoleAdmin {
+			return nil, ErrReviewNotFound
+		}
+	}
+	return s.ReviewRepo.GetHistory(reviewID)
 }
 
 func (s *ReviewService) GetUserReviews(userID int) ([]models.Review, error) {
@@ -68,3 +154,25 @@ func (s *ReviewService) GetUserReviews(userID int) ([]models.Review, error) {
 func (s *ReviewService) DeleteReview(reviewID, userID int) error {
 	return s.ReviewRepo.Delete(reviewID, userID)
 }
+
+// GetPlaceReviews returns one page of a place's reviews, sorted by newest (default),
+// highest or lowest rating, together with the summary of all its reviews.
+func (s *ReviewService) GetPlaceReviews(entityType string, entityID int, params models.ListParams) (*models.PlaceReviewPage, error) {
+	exists, err := s.ReviewRepo.PlaceExists(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrPlaceNotFound
+	}
+
+	page, err := s.ReviewRepo.GetByEntity(entityType, entityID, params)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.ReviewRepo.GetSummary(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PlaceReviewPage{Page: page, Summary: summary}, nil
+}
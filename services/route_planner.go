@@ -0,0 +1,287 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"travel-planning/models"
+)
+
+const (
+	maxKMeansIterations  = 50
+	maxAttractionsPerDay = 2
+)
+
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// DayRoute is the visiting order for one sightseeing day and the length of the
+// closed loop hotel -> attractions -> hotel.
+type DayRoute struct {
+	Attractions []models.Attraction
+	DistanceKm  float64
+}
+
+// ClusterAttractions splits the candidates into k geographic groups using k-means on lat/lon.
+// Candidates keep their incoming (ranked) order inside every group, and groups are
+// returned in order of their best-ranked member so stronger days come first.
+func ClusterAttractions(attractions []models.Attraction, k int) [][]models.Attraction {
+	if k <= 0 || len(attractions) == 0 {
+		return nil
+	}
+	if k > len(attractions) {
+		k = len(attractions)
+	}
+
+	centroids := seedCentroids(attractions, k)
+	assignment := make([]int, len(attractions))
+	for i := range assignment {
+		assignment[i] = -1
+	}
+
+	for iter := 0; iter < maxKMeansIterations; iter++ {
+		changed := false
+		for i, a := range attractions {
+			best, bestDist := 0, math.MaxFloat64
+			for c, centroid := range centroids {
+				d := calculateDistance(a.Latitude, a.Longitude, centroid.Latitude, centroid.Longitude)
+				if d < bestDist {
+					best, bestDist = c, d
+				}
+			}
+			if assignment[i] != best {
+				assignment[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([]GeoPoint, k)
+		counts := make([]int, k)
+		for i, a := range attractions {
+			c := assignment[i]
+			sums[c].Latitude += a.Latitude
+			sums[c].Longitude += a.Longitude
+			counts[c]++
+		}
+		for c := range centroids {
+			// An empty cluster keeps its previous centroid.
+			if counts[c] > 0 {
+				centroids[c] = GeoPoint{
+					Latitude:  sums[c].Latitude / float64(counts[c]),
+					Longitude: sums[c].Longitude / float64(counts[c]),
+				}
+			}
+		}
+	}
+
+	groups := make([][]models.Attraction, k)
+	firstRank := make([]int, k)
+	for c := range firstRank {
+		firstRank[c] = math.MaxInt
+	}
+	for i, a := range attractions {
+		c := assignment[i]
+		groups[c] = append(groups[c], a)
+		if i < firstRank[c] {
+			firstRank[c] = i
+		}
+	}
+
+	order := make([]int, k)
+	for c := range order {
+		order[c] = c
+	}
+	sort.Slice(order, func(i, j int) bool { return firstRank[order[i]] < firstRank[order[j]] })
+
+	var clusters [][]models.Attraction
+	for _, c := range order {
+		if len(groups[c]) > 0 {
+			clusters = append(clusters, groups[c])
+		}
+	}
+	return clusters
+}
+
+// seedCentroids starts from the best-ranked attraction and repeatedly adds the candidate
+// farthest from every chosen seed, which keeps the result deterministic.
+func seedCentroids(attractions []models.Attraction, k int) []GeoPoint {
+	centroids := []GeoPoint{{Latitude: attractions[0].Latitude, Longitude: attractions[0].Longitude}}
+
+	for len(centroids) < k {
+		farthest, farthestDist := 0, -1.0
+		for i, a := range attractions {
+			nearest := math.MaxFloat64
+			for _, c := range centroids {
+				nearest = math.Min(nearest, calculateDistance(a.Latitude, a.Longitude, c.Latitude, c.Longitude))
+			}
+			if nearest > farthestDist {
+				farthest, farthestDist = i, nearest
+			}
+		}
+		centroids = append(centroids, GeoPoint{
+			Latitude:  attractions[farthest].Latitude,
+			Longitude: attractions[farthest].Longitude,
+		})
+	}
+	return centroids
+}
+
+// OptimizeRoute orders attractions as a loop starting and ending at base,
+// using a nearest-neighbour tour improved with 2-opt.
+func OptimizeRoute(base GeoPoint, attractions []models.Attraction) DayRoute {
+	if len(attractions) == 0 {
+		return DayRoute{}
+	}
+
+	remaining := append([]models.Attraction(nil), attractions...)
+	tour := make([]models.Attraction, 0, len(attractions))
+	current := base
+	for len(remaining) > 0 {
+		next, nextDist := 0, math.MaxFloat64
+		for i, a := range remaining {
+			d := calculateDistance(current.Latitude, current.Longitude, a.Latitude, a.Longitude)
+			if d < nextDist {
+				next, nextDist = i, d
+			}
+		}
+		tour = append(tour, remaining[next])
+		current = GeoPoint{Latitude: remaining[next].Latitude, Longitude: remaining[next].Longitude}
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+
+	points := make([]GeoPoint, 0, len(tour)+2)
+	points = append(points, base)
+	for _, a := range tour {
+		points = append(points, GeoPoint{Latitude: a.Latitude, Longitude: a.Longitude})
+	}
+	points = append(points, base)
+
+	// points[0] and points[len-1] are the hotel and stay fixed.
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < len(points)-2; i++ {
+			for j := i + 1; j < len(points)-1; j++ {
+				delta := pointDistance(points[i-1], points[j]) + pointDistance(points[i], points[j+1]) -
+					pointDistance(points[i-1], points[i]) - pointDistance(points[j], points[j+1])
+				if delta < -1e-9 {
+					reversePoints(points, i, j)
+					reverseAttractions(tour, i-1, j-1)
+					improved = true
+				}
+			}
+		}
+	}
+
+	return DayRoute{Attractions: tour, DistanceKm: routeDistance(points)}
+}
+
+func pointDistance(a, b GeoPoint) float64 {
+	return calculateDistance(a.Latitude, a.Longitude, b.Latitude, b.Longitude)
+}
+
+func routeDistance(points []GeoPoint) float64 {
+	total := 0.0
+	for i := 1; i < len(points); i++ {
+		total += pointDistance(points[i-1], points[i])
+	}
+	return total
+}
+
+func reversePoints(points []GeoPoint, i, j int) {
+	for ; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+}
+
+func reverseAttractions(attractions []models.Attraction, i, j int) {
+	for ; i < j; i, j = i+1, j-1 {
+		attractions[i], attractions[j] = attractions[j], attractions[i]
+	}
+}
+
+func centroidOf(attractions []models.Attraction) GeoPoint {
+	var p GeoPoint
+	if len(attractions) == 0 {
+		return p
+	}
+	for _, a := range attractions {
+		p.Latitude += a.Latitude
+		p.Longitude += a.Longitude
+	}
+	p.Latitude /= float64(len(attractions))
+	p.Longitude /= float64(len(attractions))
+	return p
+}
+
+// PlanDayRoutes clusters the candidates into one group per sightseeing day, keeps the
+// best-ranked attractions of each group that fit the daily fee limit and orders them.
+// Days left short, because duplicate or missing coordinates collapsed their cluster,
+// are topped up with the leftover attractions nearest to what the day already visits.
+func PlanDayRoutes(attractions []models.Attraction, days int, base GeoPoint, dailyFeeLimit float64) []DayRoute {
+	selected := make([][]models.Attraction, days)
+	spent := make([]float64, days)
+	used := make(map[int]bool)
+	add := func(d int, a models.Attraction) {
+		selected[d] = append(selected[d], a)
+		spent[d] += a.EntryFee
+		used[a.AttractionID] = true
+	}
+
+	for d, cluster := range ClusterAttractions(attractions, days) {
+		for _, a := range cluster {
+			if len(selected[d]) == maxAttractionsPerDay {
+				break
+			}
+			if fitsDay(selected[d], spent[d], a, dailyFeeLimit) {
+				add(d, a)
+			}
+		}
+	}
+
+	// Bring every day up to n stops before any day gets n+1, so empty days are served first.
+	for n := 1; n <= maxAttractionsPerDay; n++ {
+		for d := range selected {
+			if len(selected[d]) >= n {
+				continue
+			}
+			if next := nearestLeftover(attractions, used, selected[d], spent[d], dailyFeeLimit); next >= 0 {
+				add(d, attractions[next])
+			}
+		}
+	}
+
+	routes := make([]DayRoute, days)
+	for d := range selected {
+		routes[d] = OptimizeRoute(base, selected[d])
+	}
+	return routes
+}
+
+// fitsDay reports whether a fits the daily fee limit; a day's first attraction always does.
+func fitsDay(selected []models.Attraction, spent float64, a models.Attraction, dailyFeeLimit float64) bool {
+	return len(selected) == 0 || spent+a.EntryFee <= dailyFeeLimit
+}
+
+// nearestLeftover returns the index of the unused attraction that fits the day and lies
+// closest to the attractions already selected for it, or the best-ranked one for an empty
+// day. It returns -1 when nothing is left.
+func nearestLeftover(attractions []models.Attraction, used map[int]bool, selected []models.Attraction, spent, dailyFeeLimit float64) int {
+	center := centroidOf(selected)
+	best, bestDist := -1, math.MaxFloat64
+	for i, a := range attractions {
+		if used[a.AttractionID] || !fitsDay(selected, spent, a, dailyFeeLimit) {
+			continue
+		}
+		if len(selected) == 0 {
+			return i
+		}
+		if d := calculateDistance(center.Latitude, center.Longitude, a.Latitude, a.Longitude); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
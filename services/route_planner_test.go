@@ -0,0 +1,183 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"travel-planning/models"
+)
+
+func attractionAt(id int, lat, lon, fee float64) models.Attraction {
+	return models.Attraction{AttractionID: id, Latitude: lat, Longitude: lon, EntryFee: fee}
+}
+
+func attractionIDs(attractions []models.Attraction) []int {
+	ids := make([]int, 0, len(attractions))
+	for _, a := range attractions {
+		ids = append(ids, a.AttractionID)
+	}
+	return ids
+}
+
+func TestClusterAttractions(t *testing.T) {
+	tests := []struct {
+		name        string
+		attractions []models.Attraction
+		k           int
+		want        [][]int
+	}{
+		{"no days", []models.Attraction{attractionAt(1, 0, 0, 0)}, 0, nil},
+		{"no attractions", nil, 2, nil},
+		{
+			"two areas, best-ranked area first",
+			[]models.Attraction{
+				attractionAt(1, 48.85, 2.35, 0), attractionAt(2, 48.89, 2.24, 0),
+				attractionAt(3, 48.86, 2.34, 0), attractionAt(4, 48.88, 2.25, 0),
+			},
+			2,
+			[][]int{{1, 3}, {2, 4}},
+		},
+		{
+			"more days than attractions",
+			[]models.Attraction{attractionAt(1, 0, 0, 0), attractionAt(2, 1, 1, 0)},
+			5,
+			[][]int{{1}, {2}},
+		},
+		{
+			"duplicate coordinates collapse into one group",
+			[]models.Attraction{attractionAt(1, 0, 0, 0), attractionAt(2, 0, 0, 0), attractionAt(3, 0, 0, 0)},
+			3,
+			[][]int{{1, 2, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]int
+			for _, c := range ClusterAttractions(tt.attractions, tt.k) {
+				got = append(got, attractionIDs(c))
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("ClusterAttractions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanDayRoutes(t *testing.T) {
+	tests := []struct {
+		name        string
+		attractions []models.Attraction
+		days        int
+		feeLimit    float64
+		want        [][]int
+	}{
+		{
+			"one area per day",
+			[]models.Attraction{
+				attractionAt(1, 48.85, 2.35, 0), attractionAt(2, 48.89, 2.24, 0),
+				attractionAt(3, 48.86, 2.34, 0), attractionAt(4, 48.88, 2.25, 0),
+			},
+			2, 100,
+			[][]int{{1, 3}, {2, 4}},
+		},
+		{
+			"duplicate coordinates still fill every day",
+			[]models.Attraction{
+				attractionAt(1, 0, 0, 0), attractionAt(2, 0, 0, 0), attractionAt(3, 0, 0, 0),
+				attractionAt(4, 0, 0, 0), attractionAt(5, 0, 0, 0), attractionAt(6, 0, 0, 0),
+			},
+			3, 100,
+			[][]int{{1, 2}, {3, 5}, {4, 6}},
+		},
+		{
+			"empty days are served before short ones",
+			[]models.Attraction{attractionAt(1, 0, 0, 0), attractionAt(2, 0, 0, 0), attractionAt(3, 0, 0, 0)},
+			3, 100,
+			[][]int{{1, 2}, {3}, nil},
+		},
+		{
+			"short day takes the nearest leftover",
+			[]models.Attraction{
+				attractionAt(1, 0, 0, 0), attractionAt(2, 0, 0.01, 0), attractionAt(3, 0, 0.02, 0),
+				attractionAt(4, 10, 10, 0), attractionAt(5, 0, 0.03, 0),
+			},
+			2, 100,
+			[][]int{{1, 2}, {4, 5}},
+		},
+		{
+			"fee limit skips expensive extras",
+			[]models.Attraction{attractionAt(1, 0, 0, 10), attractionAt(2, 0, 0.01, 30), attractionAt(3, 0, 0.02, 5)},
+			1, 20,
+			[][]int{{1, 3}},
+		},
+		{
+			"first attraction of a day ignores the fee limit",
+			[]models.Attraction{attractionAt(1, 0, 0, 50), attractionAt(2, 0, 0.01, 30)},
+			1, 20,
+			[][]int{{1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := PlanDayRoutes(tt.attractions, tt.days, GeoPoint{}, tt.feeLimit)
+			if len(routes) != tt.days {
+				t.Fatalf("got %d routes, want %d", len(routes), tt.days)
+			}
+			var got [][]int
+			for _, r := range routes {
+				ids := attractionIDs(r.Attractions)
+				slices.Sort(ids)
+				if len(ids) == 0 {
+					ids = nil
+				}
+				got = append(got, ids)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("PlanDayRoutes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptimizeRoute(t *testing.T) {
+	base := GeoPoint{}
+	loop := func(points ...GeoPoint) float64 {
+		all := append(append([]GeoPoint{base}, points...), base)
+		return routeDistance(all)
+	}
+
+	tests := []struct {
+		name        string
+		attractions []models.Attraction
+		want        []int
+		wantKm      float64
+	}{
+		{"empty", nil, nil, 0},
+		{
+			"points on a line are visited outwards",
+			[]models.Attraction{attractionAt(3, 0, 0.3, 0), attractionAt(1, 0, 0.1, 0), attractionAt(2, 0, 0.2, 0)},
+			[]int{1, 2, 3},
+			loop(GeoPoint{0, 0.3}),
+		},
+		{
+			"square loop without crossing legs",
+			[]models.Attraction{
+				attractionAt(1, 0, 0.1, 0), attractionAt(2, 0.1, 0.1, 0),
+				attractionAt(3, 0.1, 0.2, 0), attractionAt(4, 0, 0.2, 0),
+			},
+			[]int{1, 4, 3, 2},
+			loop(GeoPoint{0, 0.1}, GeoPoint{0, 0.2}, GeoPoint{0.1, 0.2}, GeoPoint{0.1, 0.1}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := OptimizeRoute(base, tt.attractions)
+			if got := attractionIDs(route.Attractions); !slices.Equal(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+			if math.Abs(route.DistanceKm-tt.wantKm) > 1e-6 {
+				t.Errorf("distance = %.3f km, want %.3f km", route.DistanceKm, tt.wantKm)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"travel-planning/models"
+)
+
+// ErrTripNotFound is returned both for missing trips and for trips the user has no
+// access to, so callers cannot probe for other users' trips.
+var ErrTripNotFound = errors.New("trip not found")
+
+var ErrTripForbidden = errors.New("insufficient trip role")
+
+var ErrInvalidTripRole = errors.New("invalid trip role")
+
+// ErrInviteeNotFound is returned when an invite names no registered user, or the trip's owner.
+var ErrInviteeNotFound = errors.New("invitee not found")
+
+var tripRoleRank = map[string]int{
+	models.TripRoleViewer: 1,
+	models.TripRoleEditor: 2,
+	models.TripRoleOwner:  3,
+}
+
+// AuthorizeTrip checks that the user has at least the required role on the trip
+// and returns the role they actually have.
+func (s *TripPlanningService) AuthorizeTrip(tripID, userID int, required string) (string, error) {
+	role, err := s.TripMemberRepo.GetRole(tripID, userID)
+	if err != nil {
+		return "", err
+	}
+	if role == "" {
+		slog.Warn("Trip access by non-member", "trip_id", tripID, "user_id", userID)
+		return "", ErrTripNotFound
+	}
+	if tripRoleRank[role] < tripRoleRank[required] {
+		slog.Warn("Trip access with insufficient role", "trip_id", tripID, "user_id", userID, "role", role, "required", required)
+		return role, ErrTripForbidden
+	}
+	return role, nil
+}
+
+// AuthorizeItinerary resolves the trip an itinerary day belongs to and checks the user's role on it.
+func (s *TripPlanningService) AuthorizeItinerary(itineraryID, userID int, required string) (string, error) {
+	tripID, err := s.ItineraryRepo.GetTripIDByItineraryID(itineraryID)
+	if err != nil {
+		return "", err
+	}
+	if tripID == 0 {
+		return "", ErrTripNotFound
+	}
+	return s.AuthorizeTrip(tripID, userID, required)
+}
+
+// AuthorizeActivity resolves the trip an itinerary activity belongs to and checks the user's role on it.
+func (s *TripPlanningService) AuthorizeActivity(activityID int64, userID int, required string) (string, error) {
+	tripID, err := s.ItineraryActivitiesRepo.GetTripIDByActivityID(activityID)
+	if err != nil {
+		return "", err
+	}
+	if tripID == 0 {
+		return "", ErrTripNotFound
+	}
+	return s.AuthorizeTrip(tripID, userID, required)
+}
+
+func (s *TripPlanningService) GetTripMembers(tripID int) ([]models.TripMember, error) {
+	return s.TripMemberRepo.GetByTripID(tripID)
+}
+
+// InviteTripMember shares the trip with a registered user, or changes the role of an existing member.
+func (s *TripPlanningService) InviteTripMember(tripID, inviterID int, req models.TripInviteRequest) (int, error) {
+	l := slog.With("trip_id", tripID, "inviter_id", inviterID, "role", req.Role)
+
+	if req.Role != models.TripRoleEditor && req.Role != models.TripRoleViewer {
+		return 0, fmt.Errorf("%w: role must be %s or %s", ErrInvalidTripRole, models.TripRoleEditor, models.TripRoleViewer)
+	}
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return 0, fmt.Errorf("%w: email is required", ErrInvalidTripRole)
+	}
+
+	userID, err := s.TripMemberRepo.UpsertByEmail(tripID, email, req.Role, inviterID)
+	if err != nil {
+		l.Error("Failed to invite trip member", "error", err)
+		return 0, err
+	}
+	if userID == 0 {
+		l.Warn("Invite for unknown user or trip owner", "email", email)
+		return 0, fmt.Errorf("%w: no other registered user with email %s", ErrInviteeNotFound, email)
+	}
+
+	l.Info("Trip shared", "member_id", userID)
+	return userID, nil
+}
+
+// RemoveTripMember revokes a membership. Owners can remove anyone; members can only leave.
+func (s *TripPlanningService) RemoveTripMember(tripID, actorID, memberID int, actorRole string) error {
+	if actorRole != models.TripRoleOwner && actorID != memberID {
+		return ErrTripForbidden
+	}
+
+	removed, err := s.TripMemberRepo.Delete(tripID, memberID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("user %d is not a member of trip %d", memberID, tripID)
+	}
+
+	slog.Info("Trip member removed", "trip_id", tripID, "member_id", memberID, "by", actorID)
+	return nil
+}
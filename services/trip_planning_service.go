@@ -3,19 +3,43 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 	"math"
 	"strings"
 	"time"
+	"travel-planning/internal/events"
 	"travel-planning/internal/kafka"
 	"travel-planning/models"
 	"travel-planning/repository"
 )
 
+var ErrTripOptionNotFound = errors.New("trip option not found")
+
+// ErrTripAlreadyFinalized is returned when selecting an option for a confirmed or completed trip.
+var ErrTripAlreadyFinalized = errors.New("trip is already finalized")
+
+var ErrInvalidPlanningTransition = errors.New("invalid planning status transition")
+
+var ErrInvalidTripLegs = errors.New("invalid trip legs")
+
+var ErrInvalidTravelers = errors.New("invalid traveler count")
+
+// planningTransitions lists, for every planning status, the states a trip may enter it from.
+var planningTransitions = map[string][]string{
+	models.PlanningQueued:       {models.PlanningFailed, models.PlanningOptionsReady},
+	models.PlanningGenerating:   {models.PlanningQueued, models.PlanningFailed, models.PlanningOptionsReady},
+	models.PlanningOptionsReady: {models.PlanningGenerating},
+	models.PlanningFailed:       {models.PlanningQueued, models.PlanningGenerating},
+}
+
 type TripPlanningService struct {
 	TripRepo                *repository.TripRepository
+	TripOptionRepo          *repository.TripOptionRepository
+	TripLegRepo             *repository.TripLegRepository
+	TripMemberRepo          *repository.TripMemberRepository
 	ItineraryRepo           *repository.TripItineraryRepository
 	ItineraryActivitiesRepo *repository.ItineraryActivitiesRepository
 
@@ -23,32 +47,51 @@ type TripPlanningService struct {
 	HotelRepo      *repository.HotelRepository
 	AttractionRepo *repository.AttractionRepository
 	RestaurantRepo *repository.RestaurantRepository
+	CityRepo       *repository.CityRepository
 
 	UserPreferencesRepo *repository.UserPreferencesRepository
+	BudgetProfileRepo   *repository.BudgetProfileRepository
 
-	KafkaProducer *kafka.Producer
+	KafkaProducer  *kafka.Producer
+	Events         *events.Hub
+	Scheduler      *DayScheduler
+	ScoringWeights AttractionScoringWeights
 }
 
 func NewTripPlanningService(
 	tripRepo *repository.TripRepository,
+	tripOptionRepo *repository.TripOptionRepository,
+	tripLegRepo *repository.TripLegRepository,
+	tripMemberRepo *repository.TripMemberRepository,
 	itineraryRepo *repository.TripItineraryRepository,
 	itineraryActivitiesRepo *repository.ItineraryActivitiesRepository,
 	flightRepo *repository.FlightRepository,
 	hotelRepo *repository.HotelRepository,
 	attractionRepo *repository.AttractionRepository,
 	restaurantRepo *repository.RestaurantRepository,
+	cityRepo *repository.CityRepository,
 	userPreferencesRepo *repository.UserPreferencesRepository,
-	KafkaProducer *kafka.Producer) *TripPlanningService {
+	budgetProfileRepo *repository.BudgetProfileRepository,
+	KafkaProducer *kafka.Producer,
+	eventsHub *events.Hub) *TripPlanningService {
 	return &TripPlanningService{
 		TripRepo:                tripRepo,
+		TripOptionRepo:          tripOptionRepo,
+		TripLegRepo:             tripLegRepo,
+		TripMemberRepo:          tripMemberRepo,
 		ItineraryRepo:           itineraryRepo,
 		ItineraryActivitiesRepo: itineraryActivitiesRepo,
 		FlightRepo:              flightRepo,
 		HotelRepo:               hotelRepo,
 		AttractionRepo:          attractionRepo,
 		RestaurantRepo:          restaurantRepo,
+		CityRepo:                cityRepo,
 		UserPreferencesRepo:     userPreferencesRepo,
+		BudgetProfileRepo:       budgetProfileRepo,
 		KafkaProducer:           KafkaProducer,
+		Events:                  eventsHub,
+		Scheduler:               NewDayScheduler(DefaultDayScheduleConfig()),
+		ScoringWeights:          DefaultAttractionScoringWeights(),
 	}
 }
 
@@ -69,64 +112,248 @@ func (s *TripPlanningService) GenerateOptions(tripID int) ([]models.TripOption,
 		return nil, fmt.Errorf("trip must be at least 1 night")
 	}
 
-	totalBudget := trip.TotalPrice
-	logistics_budget := totalBudget * 0.50
-	oneWayBudget := (logistics_budget * 0.6) / 2
-	activities_budget := totalBudget * 0.30
-	more_money := totalBudget * 0.20
+	legs, err := s.tripLegs(trip)
+	if err != nil {
+		return nil, fmt.Errorf("could not load trip legs: %w", err)
+	}
+
+	group := travelerGroup(trip)
+	profile := s.budgetProfile(trip.UserID)
+	split := SplitBudget(trip.TotalPrice, profile)
+
+	logistics_budget := split.Logistics
+	// One flight into every leg plus the flight home, as a per-ticket limit.
+	oneWayBudget := split.Flights / float64(len(legs)+1) / group.FlightFares()
+	activities_budget := split.Activities
+	more_money := split.Extra
 
 	var options []models.TripOption
 	tiers := []string{"Economy", "Balanced", "Luxury"}
 
 	for _, tier := range tiers {
-		outboundFlight, err := s.FlightRepo.GetBestFlightByTier(originCityID, trip.DestinationCityID, oneWayBudget, tier)
-		if err != nil || outboundFlight == nil {
-			log.Printf("Error outbound flight for tier %s: %v", tier, err)
+		option, err := s.buildTripOption(originCityID, legs, tier, oneWayBudget, logistics_budget, group)
+		if err != nil {
+			log.Printf("Skip tier %s: %v", tier, err)
 			continue
 		}
 
-		inboundFlight, err := s.FlightRepo.GetBestFlightByTier(trip.DestinationCityID, originCityID, oneWayBudget, tier)
-		if err != nil || inboundFlight == nil {
-			log.Printf("Error inbound flight for tier %s: %v", tier, err)
-			continue
+		option.BudgetProfile = profile.Name
+		option.ActivitiesBudget = activities_budget
+		option.MoreMoney = more_money + (logistics_budget - option.LogisticsBudget)
+		option.TotalPriceOfTrip = option.LogisticsBudget + activities_budget + more_money
+		option.PerPersonPrice = option.TotalPriceOfTrip / float64(group.Travelers())
+		options = append(options, *option)
+	}
+	if len(options) == 0 {
+		return nil, fmt.Errorf("could not generate any trip options within your budget")
+	}
+
+	saved, err := s.TripOptionRepo.ReplaceForTrip(tripID, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save trip options: %w", err)
+	}
+	return saved, nil
+}
+
+// buildTripOption picks the flight into every leg, the flight home and one hotel per leg for a tier.
+// Hotels share what is left of the logistics budget after the group's fares, per room and night.
+func (s *TripPlanningService) buildTripOption(originCityID int, legs []models.TripLeg, tier string, oneWayBudget, logisticsBudget float64, group TravelerGroup) (*models.TripOption, error) {
+	fares := group.FlightFares()
+	rooms := group.Rooms()
+	optionLegs := make([]models.TripOptionLeg, len(legs))
+	flightsCost := 0.0
+	nights := 0
+	fromCityID := originCityID
+
+	for i, leg := range legs {
+		flight, err := s.FlightRepo.GetBestFlightByTier(fromCityID, leg.CityID, oneWayBudget, tier)
+		if err != nil || flight == nil {
+			return nil, fmt.Errorf("no flight from city %d to city %d: %v", fromCityID, leg.CityID, err)
 		}
+		flightsCost += flight.Price * fares
+		nights += leg.Nights()
+		optionLegs[i] = models.TripOptionLeg{
+			LegOrder:      leg.LegOrder,
+			CityID:        leg.CityID,
+			Nights:        leg.Nights(),
+			ArrivalFlight: flight,
+		}
+		fromCityID = leg.CityID
+	}
 
-		totalFLightsCost := outboundFlight.Price + inboundFlight.Price
-		remainingMoneyForHotel := logistics_budget - totalFLightsCost
-		limitPerNight := remainingMoneyForHotel / float64(nights)
+	inboundFlight, err := s.FlightRepo.GetBestFlightByTier(fromCityID, originCityID, oneWayBudget, tier)
+	if err != nil || inboundFlight == nil {
+		return nil, fmt.Errorf("no flight home from city %d: %v", fromCityID, err)
+	}
+	flightsCost += inboundFlight.Price * fares
 
-		if limitPerNight <= 0 {
-			log.Printf("Skip tier %s: no money left for hotel", tier)
-			continue
+	limitPerNight := (logisticsBudget - flightsCost) / float64(nights) / float64(rooms)
+	if limitPerNight <= 0 {
+		return nil, fmt.Errorf("no money left for hotel")
+	}
+
+	hotelCost := 0.0
+	for i := range optionLegs {
+		hotel, err := s.HotelRepo.GetBestHotelByTier(optionLegs[i].CityID, limitPerNight, tier)
+		if err != nil || hotel == nil {
+			return nil, fmt.Errorf("no hotel in city %d: %v", optionLegs[i].CityID, err)
 		}
+		optionLegs[i].Hotel = hotel
+		hotelCost += hotel.PricePerNight * float64(optionLegs[i].Nights) * float64(rooms)
+	}
 
-		hotel, err := s.HotelRepo.GetBestHotelByTier(trip.DestinationCityID, limitPerNight, tier)
+	return &models.TripOption{
+		Tier:            tier,
+		OutBoundFlight:  optionLegs[0].ArrivalFlight,
+		InBoundFlight:   inboundFlight,
+		Hotel:           optionLegs[0].Hotel,
+		LogisticsBudget: flightsCost + hotelCost,
+		Legs:            optionLegs,
+		Adults:          group.Adults,
+		Children:        group.Children,
+		Rooms:           rooms,
+		FlightsCost:     flightsCost,
+		HotelCost:       hotelCost,
+	}, nil
+}
+
+// budgetProfile returns the allocation profile the user plans trips with.
+func (s *TripPlanningService) budgetProfile(userID int) models.BudgetProfile {
+	if s.BudgetProfileRepo != nil {
+		profile, err := s.BudgetProfileRepo.GetForUser(userID)
+		if err == nil && profile != nil && ValidateBudgetProfile(*profile) == nil {
+			return *profile
+		}
 		if err != nil {
-			log.Printf("Error finding hotel for tier %s: %v", tier, err)
-			continue
+			slog.Warn("Could not load budget profile, using default split", "user_id", userID, "error", err)
 		}
+	}
+	return DefaultBudgetProfile()
+}
+
+// travelerGroup reads the party size of a trip; trips created before group
+// planning count as one adult.
+func travelerGroup(trip *models.Trip) TravelerGroup {
+	group := TravelerGroup{Adults: trip.Adults, Children: trip.Children}
+	if group.Adults < 1 {
+		group.Adults = 1
+	}
+	return group
+}
+
+// tripLegs returns the stored legs of a trip in travel order.
+// Trips without legs are a single stay in the destination city.
+func (s *TripPlanningService) tripLegs(trip *models.Trip) ([]models.TripLeg, error) {
+	legs, err := s.TripLegRepo.GetByTripID(trip.TripID)
+	if err != nil {
+		return nil, err
+	}
+	if len(legs) > 0 {
+		return legs, nil
+	}
+	return []models.TripLeg{{
+		TripID:        trip.TripID,
+		CityID:        trip.DestinationCityID,
+		ArrivalDate:   trip.StartDate,
+		DepartureDate: trip.EndDate,
+	}}, nil
+}
 
-		hotelCost := hotel.PricePerNight * float64(nights)
-		actualLogisticsCost := totalFLightsCost + hotelCost
-
-		option := models.TripOption{
-			Tier:             tier,
-			OutBoundFlight:   outboundFlight,
-			InBoundFlight:    inboundFlight,
-			Hotel:            hotel,
-			LogisticsBudget:  actualLogisticsCost,
-			ActivitiesBudget: activities_budget,
-			MoreMoney:        more_money + (logistics_budget - actualLogisticsCost),
-			TotalPriceOfTrip: actualLogisticsCost + activities_budget + more_money,
+func (s *TripPlanningService) GetTripLegs(tripID int) ([]models.TripLeg, error) {
+	trip, err := s.TripRepo.GetTripByID(tripID)
+	if err != nil {
+		return nil, err
+	}
+	return s.tripLegs(trip)
+}
+
+// UpdatePlanningStatus records a planning job transition and notifies clients watching the trip.
+func (s *TripPlanningService) UpdatePlanningStatus(tripID int, status string, reason string) error {
+	allowedFrom, ok := planningTransitions[status]
+	if !ok {
+		return fmt.Errorf("unknown planning status %q", status)
+	}
+
+	applied, err := s.TripRepo.UpdatePlanningStatus(tripID, status, reason, allowedFrom)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		slog.Warn("Planning status transition rejected", "trip_id", tripID, "status", status)
+		return fmt.Errorf("%w: trip %d to %s", ErrInvalidPlanningTransition, tripID, status)
+	}
+
+	slog.Info("Planning status changed", "trip_id", tripID, "status", status, "reason", reason)
+	if s.Events != nil {
+		s.Events.Publish(models.PlanningEvent{
+			TripID:    tripID,
+			Status:    status,
+			Reason:    reason,
+			UpdatedAt: time.Now(),
+		})
+	}
+	return nil
+}
+
+// GenerateOptionsNow runs generation synchronously for the HTTP endpoint,
+// going through the same planning states as the Kafka consumer. A trip whose options
+// are already being generated returns ErrInvalidPlanningTransition.
+func (s *TripPlanningService) GenerateOptionsNow(tripID int) ([]models.TripOption, error) {
+	if err := s.UpdatePlanningStatus(tripID, models.PlanningGenerating, ""); err != nil {
+		return nil, err
+	}
+
+	options, err := s.GenerateOptions(tripID)
+	if err != nil {
+		if statusErr := s.UpdatePlanningStatus(tripID, models.PlanningFailed, err.Error()); statusErr != nil {
+			slog.Error("Failed to record planning failure", "trip_id", tripID, "error", statusErr)
 		}
-		options = append(options, option)
+		return nil, err
 	}
-	if len(options) == 0 {
-		return nil, fmt.Errorf("could not generate any trip options within your budget")
+
+	// The options are stored already; a lost status update must not hide them from the caller.
+	if err := s.UpdatePlanningStatus(tripID, models.PlanningOptionsReady, ""); err != nil {
+		slog.Error("Failed to record generated options", "trip_id", tripID, "error", err)
 	}
 	return options, nil
 }
 
+func (s *TripPlanningService) GetPlanningEvent(tripID int) (*models.PlanningEvent, error) {
+	trip, err := s.TripRepo.GetTripByID(tripID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PlanningEvent{
+		TripID:    trip.TripID,
+		Status:    trip.PlanningStatus,
+		Reason:    trip.PlanningError,
+		UpdatedAt: trip.PlanningUpdatedAt,
+	}, nil
+}
+
+func (s *TripPlanningService) SubscribePlanningEvents(tripID int) (<-chan models.PlanningEvent, func()) {
+	return s.Events.Subscribe(tripID)
+}
+
+func (s *TripPlanningService) GetTripOptions(tripID int) ([]models.TripOption, error) {
+	return s.TripOptionRepo.GetByTripID(tripID)
+}
+
+// SelectTripOption finalizes the trip with one of its stored options, so clients can only
+// pick flights and hotels that were actually generated for this trip.
+func (s *TripPlanningService) SelectTripOption(tripID, optionID int) error {
+	option, err := s.TripOptionRepo.GetByIDAndTripID(optionID, tripID)
+	if err != nil {
+		return err
+	}
+	if option == nil {
+		slog.Warn("Rejected selection of unknown trip option", "trip_id", tripID, "option_id", optionID)
+		return ErrTripOptionNotFound
+	}
+
+	return s.finalizeOption(tripID, option)
+}
+
 func (s *TripPlanningService) PlanTrip(userID int, req models.TripPlanRequest) (int, error) {
 	l := slog.With("user_id", userID, "trip_name", req.Name)
 	l.Info("Starting trip planning")
@@ -144,6 +371,20 @@ func (s *TripPlanningService) PlanTrip(userID int, req models.TripPlanRequest) (
 		return 0, fmt.Errorf("end date must be after start date")
 	}
 
+	if req.Adults == 0 {
+		req.Adults = 1
+	}
+	if req.Adults < 1 || req.Children < 0 {
+		l.Warn("Invalid traveler count", "adults", req.Adults, "children", req.Children)
+		return 0, fmt.Errorf("%w: at least one adult is required", ErrInvalidTravelers)
+	}
+
+	legs, err := buildTripLegs(req, startDate, endDate)
+	if err != nil {
+		l.Warn("Invalid trip legs", "error", err)
+		return 0, err
+	}
+
 	tx, err := s.TripRepo.GetConn().Begin()
 	if err != nil {
 		l.Error("Failed to start transaction", "error", err)
@@ -156,8 +397,10 @@ func (s *TripPlanningService) PlanTrip(userID int, req models.TripPlanRequest) (
 		Title:             req.Name,
 		StartDate:         startDate,
 		EndDate:           endDate,
-		DestinationCityID: req.ToCityID,
+		DestinationCityID: legs[0].CityID,
 		TotalPrice:        req.BudgetAmount,
+		Adults:            req.Adults,
+		Children:          req.Children,
 		Status:            "Planned",
 	}
 
@@ -169,6 +412,14 @@ func (s *TripPlanningService) PlanTrip(userID int, req models.TripPlanRequest) (
 
 	l.Info("Trip header created", "trip_id", tripID)
 
+	for i := range legs {
+		legs[i].TripID = tripID
+		if _, err := s.TripLegRepo.Insert(tx, &legs[i]); err != nil {
+			l.Error("DB error: failed to insert trip leg", "leg_order", legs[i].LegOrder, "error", err)
+			return 0, fmt.Errorf("failed to insert trip legs: %w", err)
+		}
+	}
+
 	numDays := int(duration) + 1
 	for day := 0; day < numDays; day++ {
 		itineraryDate := startDate.AddDate(0, 0, day)
@@ -192,106 +443,375 @@ func (s *TripPlanningService) PlanTrip(userID int, req models.TripPlanRequest) (
 		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 	if s.KafkaProducer != nil {
-		go s.KafkaProducer.PublishTripReques(context.Background(), tripID, userID, req.ToCityID)
+		go func() {
+			if err := s.KafkaProducer.PublishTripReques(context.Background(), tripID, userID, newTrip.DestinationCityID); err != nil {
+				if statusErr := s.UpdatePlanningStatus(tripID, models.PlanningFailed, "could not queue trip for planning"); statusErr != nil {
+					l.Error("Failed to record planning failure", "trip_id", tripID, "error", statusErr)
+				}
+			}
+		}()
 	}
 
 	return tripID, nil
 }
 
+// buildTripLegs checks that the requested legs cover the trip back to back, each with at
+// least one night. A request without legs is a single stay in the destination city.
+func buildTripLegs(req models.TripPlanRequest, startDate, endDate time.Time) ([]models.TripLeg, error) {
+	if len(req.Legs) == 0 {
+		return []models.TripLeg{{CityID: req.ToCityID, ArrivalDate: startDate, DepartureDate: endDate}}, nil
+	}
+
+	legs := make([]models.TripLeg, 0, len(req.Legs))
+	expectedArrival := startDate
+	for i, r := range req.Legs {
+		arrival, err1 := time.Parse("2006-01-02", r.ArrivalDate)
+		departure, err2 := time.Parse("2006-01-02", r.DepartureDate)
+		switch {
+		case err1 != nil || err2 != nil:
+			return nil, fmt.Errorf("%w: leg %d has invalid dates, use Year-month-day", ErrInvalidTripLegs, i+1)
+		case r.CityID <= 0:
+			return nil, fmt.Errorf("%w: leg %d has no city", ErrInvalidTripLegs, i+1)
+		case i > 0 && r.CityID == legs[i-1].CityID:
+			return nil, fmt.Errorf("%w: leg %d is in the same city as the previous leg", ErrInvalidTripLegs, i+1)
+		case !arrival.Equal(expectedArrival):
+			return nil, fmt.Errorf("%w: leg %d must arrive on %s", ErrInvalidTripLegs, i+1, expectedArrival.Format("2006-01-02"))
+		case !departure.After(arrival):
+			return nil, fmt.Errorf("%w: leg %d must last at least one night", ErrInvalidTripLegs, i+1)
+		}
+
+		legs = append(legs, models.TripLeg{
+			LegOrder:      i,
+			CityID:        r.CityID,
+			ArrivalDate:   arrival,
+			DepartureDate: departure,
+		})
+		expectedArrival = departure
+	}
+
+	if !expectedArrival.Equal(endDate) {
+		return nil, fmt.Errorf("%w: last leg must depart on %s", ErrInvalidTripLegs, endDate.Format("2006-01-02"))
+	}
+	return legs, nil
+}
+
+// legPlan holds what is needed to lay out the days spent in one city of the trip.
+type legPlan struct {
+	leg         models.TripLeg
+	arrival     ScheduleStop
+	hotelStop   ScheduleStop
+	base        GeoPoint
+	attractions []models.Attraction
+	restaurants []models.Restaurant
+	matched     map[int]bool
+	routes      []DayRoute
+	nextRoute   int
+}
+
 func (s *TripPlanningService) PopulateItineraryDetails(tx *sql.Tx,
-	tripID int,
-	cityID int,
-	tier string,
-	totalActivitiesBudget float64,
-	days int,
-	startDate time.Time,
-	hotelID int,
-	outboundFlightID int,
-	inboundFlightID int) error {
-
-	l := slog.With("trip_id", tripID, "tier", tier)
+	trip *models.Trip,
+	option *models.TripOption,
+	totalActivitiesBudget float64) error {
+
+	tier := option.Tier
+	l := slog.With("trip_id", trip.TripID, "tier", tier)
 	l.Debug("Populating itinerary details")
 
-	itineraries, err := s.ItineraryRepo.GetItineraryDaysByTripID(tripID)
+	itineraries, err := s.ItineraryRepo.GetItineraryDaysByTripID(trip.TripID)
 	if err != nil || len(itineraries) == 0 {
-		return fmt.Errorf("no itinerary days found for trip %d", tripID)
+		return fmt.Errorf("no itinerary days found for trip %d", trip.TripID)
+	}
+
+	legs, err := s.tripLegs(trip)
+	if err != nil {
+		return fmt.Errorf("could not load trip legs: %w", err)
+	}
+
+	optionLegs := option.Legs
+	if len(optionLegs) == 0 && len(legs) == 1 {
+		optionLegs = []models.TripOptionLeg{{
+			CityID:        legs[0].CityID,
+			Nights:        legs[0].Nights(),
+			Hotel:         option.Hotel,
+			ArrivalFlight: option.OutBoundFlight,
+		}}
+	}
+	if len(optionLegs) != len(legs) {
+		return fmt.Errorf("option %s covers %d legs but trip %d has %d", tier, len(optionLegs), trip.TripID, len(legs))
 	}
 
 	totalDays := len(itineraries)
-	dailyBudget := totalActivitiesBudget / float64(days)
-	dailyAttractionLimit := dailyBudget * 0.70
+	dailyAttractionLimit := attractionFeeLimit(trip, totalActivitiesBudget, totalDays)
+
+	preferred := s.preferredCategories(trip.UserID)
+	weights := s.ScoringWeights.ForTier(tier)
 
-	allAttractions, err := s.AttractionRepo.GetBestAttractionsByTier(cityID, dailyAttractionLimit, tier)
-	if err != nil || len(allAttractions) == 0 {
-		l.Warn("No attractions found for criteria", "city_id", cityID, "limit", dailyAttractionLimit)
-		return fmt.Errorf("no attractions found for city %d", cityID)
+	// Each day belongs to the leg whose stay covers it; the arrival day of a leg is a transfer day.
+	dayLegs := make([]int, totalDays)
+	sightseeingDays := make([]int, len(legs))
+	for i, dayPlan := range itineraries {
+		dayLegs[i] = legIndexFor(legs, dayPlan.Date)
+		if i != 0 && i != totalDays-1 && !sameDate(dayPlan.Date, legs[dayLegs[i]].ArrivalDate) {
+			sightseeingDays[dayLegs[i]]++
+		}
 	}
 
-	allRestaurants, err := s.RestaurantRepo.GetBestRestaurantByTier(cityID, tier)
-	if err != nil || len(allRestaurants) == 0 {
-		slog.Warn("No restaurants found for this city and tier",
-			"trip_id", tripID, "city_id", cityID, "tier", tier)
+	plans := make([]*legPlan, len(legs))
+	for li, leg := range legs {
+		plan, err := s.planLeg(leg, optionLegs[li], tier, preferred, weights, dailyAttractionLimit, sightseeingDays[li], nil)
+		if err != nil {
+			l.Warn("Could not plan leg", "leg_order", leg.LegOrder, "city_id", leg.CityID, "error", err)
+			return err
+		}
+		plans[li] = plan
 	}
 
-	usedAttractions := make(map[int]bool)
+	inboundStop := s.flightStop(option.InBoundFlight.FlightID)
 
 	for i, dayPlan := range itineraries {
 		dayNum := i + 1
 		currentDayID := int64(dayPlan.ItineraryID)
+		plan := plans[dayLegs[i]]
 
+		var stops []ScheduleStop
+		sightseeing := false
 		switch {
 		case dayNum == 1:
-			s.saveActivity(tx, currentDayID, "flight", outboundFlightID, 0, allAttractions, dayPlan.Date)
-			s.saveActivity(tx, currentDayID, "hotel", hotelID, 1, allAttractions, dayPlan.Date)
-			s.saveActivity(tx, currentDayID, "event", 0, 2, nil, dayPlan.Date)
+			stops = []ScheduleStop{
+				plan.arrival,
+				plan.hotelStop,
+				{ActivityType: "event", Notes: "Welcome. Enjoy a relaxing walk in a nearby park after your flight."},
+			}
 
 		case dayNum == totalDays:
-			s.saveActivity(tx, currentDayID, "event", 0, 1, nil, dayPlan.Date)
-			s.saveActivity(tx, currentDayID, "flight", inboundFlightID, 2, allAttractions, dayPlan.Date)
+			stops = []ScheduleStop{
+				{ActivityType: "event", Notes: "Last day. Perfect time for souvenir shopping and a final city stroll."},
+				inboundStop,
+			}
+
+		case sameDate(dayPlan.Date, plan.leg.ArrivalDate):
+			stops = []ScheduleStop{
+				plan.arrival,
+				plan.hotelStop,
+				{ActivityType: "event", Notes: "Travel day. Check in and take an easy first look around the new city."},
+			}
 
 		default:
-			var lastLat, lastLon float64
-			var firstAttraction *models.Attraction
-
-			for j := range allAttractions {
-				if !usedAttractions[allAttractions[j].AttractionID] {
-					firstAttraction = &allAttractions[j]
-					usedAttractions[firstAttraction.AttractionID] = true
-					s.saveActivity(tx, currentDayID, "attraction", firstAttraction.AttractionID, 1, allAttractions, dayPlan.Date)
-					lastLat, lastLon = firstAttraction.Latitude, firstAttraction.Longitude
-					break
-				}
+			sightseeing = true
+			if plan.nextRoute < len(plan.routes) {
+				stops = buildDayStops(plan.routes[plan.nextRoute], plan.restaurants, plan.hotelStop, plan.matched)
+				plan.nextRoute++
 			}
+		}
 
-			if firstAttraction != nil {
-				var bestRest *models.Restaurant
-				minD := 999999.0
-				for j := range allRestaurants {
-					d := calculateDistance(lastLat, lastLon, allRestaurants[j].Latitude, allRestaurants[j].Longitude)
-					if d < minD {
-						minD = d
-						bestRest = &allRestaurants[j]
-					}
-				}
-				if bestRest != nil {
-					s.saveActivity(tx, currentDayID, "restaurant", bestRest.RestaurantID, 2, nil, dayPlan.Date)
-					lastLat, lastLon = bestRest.Latitude, bestRest.Longitude
-				}
+		schedule := s.Scheduler.Schedule(dayPlan.Date, stops)
+		for order, slot := range schedule {
+			if err := s.saveActivity(tx, currentDayID, slot, order, plan.attractions); err != nil {
+				return err
+			}
+		}
 
-				for j := range allAttractions {
-					if !usedAttractions[allAttractions[j].AttractionID] && (firstAttraction.EntryFee+allAttractions[j].EntryFee <= dailyAttractionLimit) {
-						usedAttractions[allAttractions[j].AttractionID] = true
-						s.saveActivity(tx, currentDayID, "attraction", allAttractions[j].AttractionID, 3, allAttractions, dayPlan.Date)
-						break
-					}
-				}
-				s.saveActivity(tx, currentDayID, "hotel", hotelID, 4, nil, dayPlan.Date)
+		if sightseeing {
+			km := scheduledDistance(plan.base, schedule)
+			if err := s.ItineraryRepo.UpdateRouteDistance(tx, dayPlan.ItineraryID, km); err != nil {
+				return err
 			}
+			l.Debug("Day route planned", "day", dayNum, "city_id", plan.leg.CityID, "stops", len(schedule), "distance_km", math.Round(km*10)/10)
 		}
 	}
 	return nil
 }
 
+// planLeg ranks the attractions of a leg's city around its hotel and splits them into
+// one route per sightseeing day of the leg. Attractions in exclude are left out.
+func (s *TripPlanningService) planLeg(leg models.TripLeg, optionLeg models.TripOptionLeg, tier string, preferred []string,
+	weights AttractionScoringWeights, dailyAttractionLimit float64, sightseeingDays int, exclude map[int]bool) (*legPlan, error) {
+	if optionLeg.Hotel == nil || optionLeg.ArrivalFlight == nil {
+		return nil, fmt.Errorf("leg %d has no hotel or arrival flight", leg.LegOrder)
+	}
+
+	plan := &legPlan{
+		leg:       leg,
+		arrival:   s.flightStop(optionLeg.ArrivalFlight.FlightID),
+		hotelStop: ScheduleStop{ActivityType: "hotel", EntityID: optionLeg.Hotel.HotelID},
+		matched:   make(map[int]bool),
+	}
+
+	attractions, err := s.AttractionRepo.GetBestAttractionsByTier(leg.CityID, dailyAttractionLimit, tier, preferred)
+	if len(exclude) > 0 {
+		kept := attractions[:0]
+		for _, a := range attractions {
+			if !exclude[a.AttractionID] {
+				kept = append(kept, a)
+			}
+		}
+		attractions = kept
+	}
+	if (err != nil || len(attractions) == 0) && sightseeingDays > 0 {
+		return nil, fmt.Errorf("no attractions found for city %d", leg.CityID)
+	}
+	plan.attractions = attractions
+
+	plan.restaurants, err = s.RestaurantRepo.GetBestRestaurantByTier(leg.CityID, tier)
+	if err != nil || len(plan.restaurants) == 0 {
+		slog.Warn("No restaurants found for this city and tier",
+			"trip_id", leg.TripID, "city_id", leg.CityID, "tier", tier)
+	}
+
+	plan.base = centroidOf(attractions)
+	if hotel, err := s.HotelRepo.GetHotelByID(optionLeg.Hotel.HotelID); err == nil && hotel != nil && (hotel.Latitude != 0 || hotel.Longitude != 0) {
+		plan.base = GeoPoint{Latitude: hotel.Latitude, Longitude: hotel.Longitude}
+		plan.hotelStop.Latitude, plan.hotelStop.Longitude, plan.hotelStop.HasLocation = hotel.Latitude, hotel.Longitude, true
+	} else {
+		slog.Debug("Hotel has no coordinates, routing from the attractions centroid", "hotel_id", optionLeg.Hotel.HotelID)
+	}
+
+	ranked := ScoreAttractions(attractions, preferred, plan.base, dailyAttractionLimit, weights)
+	for j, a := range ranked {
+		attractions[j] = a.Attraction
+		if a.MatchesPreference {
+			plan.matched[a.AttractionID] = true
+		}
+	}
+	slog.Info("Attractions ranked by preference", "city_id", leg.CityID, "preferred", preferred, "weights", weights, "matching", len(plan.matched))
+
+	if sightseeingDays > 0 {
+		plan.routes = PlanDayRoutes(attractions, sightseeingDays, plan.base, dailyAttractionLimit)
+	}
+	return plan, nil
+}
+
+// attractionFeeLimit is the per-ticket entry fee a day can afford. Entry fees are paid
+// for the whole group, so the daily share is divided by the group's ticket weight.
+func attractionFeeLimit(trip *models.Trip, activitiesBudget float64, totalDays int) float64 {
+	return activitiesBudget / float64(totalDays) * 0.70 / travelerGroup(trip).EntryFees()
+}
+
+// legIndexFor returns the last leg that has started on the given day.
+func legIndexFor(legs []models.TripLeg, day time.Time) int {
+	idx := 0
+	for i, leg := range legs {
+		if !dateOnly(leg.ArrivalDate).After(dateOnly(day)) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+func dateOnly(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func sameDate(a, b time.Time) bool {
+	return dateOnly(a).Equal(dateOnly(b))
+}
+
+// buildDayStops turns an ordered route into the day's stops: lunch near the first
+// attraction, dinner near the last one and a return to the hotel.
+func buildDayStops(route DayRoute, allRestaurants []models.Restaurant, hotelStop ScheduleStop, matchedAttractions map[int]bool) []ScheduleStop {
+	if len(route.Attractions) == 0 {
+		return nil
+	}
+
+	var stops []ScheduleStop
+	lunchID := 0
+	for j, a := range route.Attractions {
+		stop := attractionStop(a)
+		if matchedAttractions[a.AttractionID] {
+			stop.Notes = fmt.Sprintf("Picked for your interest in %s.", strings.ToLower(a.Category))
+		}
+		stops = append(stops, stop)
+		if j == 0 {
+			if lunch := nearestRestaurant(allRestaurants, a.Latitude, a.Longitude, 0); lunch != nil {
+				lunchID = lunch.RestaurantID
+				stops = append(stops, restaurantStop(*lunch, MealLunch))
+			}
+		}
+	}
+
+	last := route.Attractions[len(route.Attractions)-1]
+	if dinner := nearestRestaurant(allRestaurants, last.Latitude, last.Longitude, lunchID); dinner != nil {
+		stops = append(stops, restaurantStop(*dinner, MealDinner))
+	}
+
+	return append(stops, hotelStop)
+}
+
+// scheduledDistance is the length of the loop base -> every located stop -> base.
+func scheduledDistance(base GeoPoint, schedule []ScheduledStop) float64 {
+	points := []GeoPoint{base}
+	for _, slot := range schedule {
+		if slot.HasLocation && slot.ActivityType != "hotel" {
+			points = append(points, GeoPoint{Latitude: slot.Latitude, Longitude: slot.Longitude})
+		}
+	}
+	points = append(points, base)
+	return routeDistance(points)
+}
+
+func nearestRestaurant(restaurants []models.Restaurant, lat, lon float64, excludeID int) *models.Restaurant {
+	var best *models.Restaurant
+	minD := 999999.0
+	for j := range restaurants {
+		if restaurants[j].RestaurantID == excludeID {
+			continue
+		}
+		d := calculateDistance(lat, lon, restaurants[j].Latitude, restaurants[j].Longitude)
+		if d < minD {
+			minD = d
+			best = &restaurants[j]
+		}
+	}
+	return best
+}
+
+func attractionStop(a models.Attraction) ScheduleStop {
+	return ScheduleStop{
+		ActivityType: "attraction",
+		EntityID:     a.AttractionID,
+		Category:     a.Category,
+		Latitude:     a.Latitude,
+		Longitude:    a.Longitude,
+		HasLocation:  true,
+	}
+}
+
+func restaurantStop(r models.Restaurant, meal string) ScheduleStop {
+	return ScheduleStop{
+		ActivityType: "restaurant",
+		EntityID:     r.RestaurantID,
+		Meal:         meal,
+		Latitude:     r.Latitude,
+		Longitude:    r.Longitude,
+		HasLocation:  true,
+	}
+}
+
+// preferredCategories loads the trip owner's preferred attraction categories.
+// A trip without preferences is planned on rating, fee and distance alone.
+func (s *TripPlanningService) preferredCategories(userID int) []string {
+	prefs, err := s.UserPreferencesRepo.GetByUserID(userID)
+	if err != nil || prefs == nil {
+		return nil
+	}
+	return ParsePreferredCategories(prefs.PreferredCategories)
+}
+
+func (s *TripPlanningService) flightStop(flightID int) ScheduleStop {
+	stop := ScheduleStop{ActivityType: "flight", EntityID: flightID}
+
+	flight, err := s.FlightRepo.GetFlightByID(flightID)
+	if err != nil || flight == nil {
+		slog.Warn("Flight details unavailable, using default duration", "flight_id", flightID, "error", err)
+		return stop
+	}
+	if flight.DurationMinutes > 0 {
+		stop.Duration = time.Duration(flight.DurationMinutes) * time.Minute
+	}
+	return stop
+}
+
 func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	const R = 6371
 
@@ -307,40 +827,16 @@ func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	return R * c
 }
 
-func (s *TripPlanningService) saveActivity(tx *sql.Tx, itineraryID int64, aType string, entityID int, order int, allAttractions []models.Attraction, dayDate time.Time) {
-	aType = strings.ToLower(aType)
-
-	year, month, day := dayDate.Date()
-	location := dayDate.Location()
-
-	var startTime, endTime time.Time
-	switch order {
-	case 0:
-		startTime = time.Date(year, month, day, 9, 0, 0, 0, location)
-		endTime = startTime.Add(2 * time.Hour)
-	case 1:
-		startTime = time.Date(year, month, day, 11, 30, 0, 0, location)
-		endTime = startTime.Add(1*time.Hour + 30*time.Minute)
-	case 2:
-		startTime = time.Date(year, month, day, 14, 0, 0, 0, location)
-		endTime = startTime.Add(1 * time.Hour)
-	case 3:
-		startTime = time.Date(year, month, day, 16, 0, 0, 0, location)
-		endTime = startTime.Add(2 * time.Hour)
-	case 4:
-		startTime = time.Date(year, month, day, 19, 0, 0, 0, location)
-		endTime = startTime.Add(2 * time.Hour)
-	default:
-		startTime = time.Date(year, month, day, 21, 0, 0, 0, location)
-		endTime = startTime.Add(1 * time.Hour)
-	}
+func (s *TripPlanningService) saveActivity(tx *sql.Tx, itineraryID int64, slot ScheduledStop, order int, allAttractions []models.Attraction) error {
+	aType := strings.ToLower(slot.ActivityType)
+	entityID := slot.EntityID
 
 	activity := &models.ItineraryActivity{
 		ItineraryID:  itineraryID,
 		ActivityType: aType,
 		OrderNumber:  order,
-		StartTime:    startTime,
-		EndTime:      endTime,
+		StartTime:    slot.StartTime,
+		EndTime:      slot.EndTime,
 		AttractionID: sql.NullInt64{Int64: 0, Valid: false},
 		RestaurantID: sql.NullInt64{Int64: 0, Valid: false},
 		HotelID:      sql.NullInt64{Int64: 0, Valid: false},
@@ -367,6 +863,7 @@ func (s *TripPlanningService) saveActivity(tx *sql.Tx, itineraryID int64, aType
 		activity.ActivityType = "attraction"
 		if entityID > 0 {
 			activity.AttractionID = sql.NullInt64{Int64: int64(entityID), Valid: true}
+			activity.Notes = slot.Notes
 			var lat, lon float64
 			found := false
 
@@ -390,16 +887,12 @@ func (s *TripPlanningService) saveActivity(tx *sql.Tx, itineraryID int64, aType
 					}
 				}
 				if backup != "" {
-					activity.Notes = fmt.Sprintf("If this place is closed, the best nearby alternative is %s.", backup)
+					activity.Notes = strings.TrimSpace(fmt.Sprintf("%s If this place is closed, the best nearby alternative is %s.", slot.Notes, backup))
 				}
 			}
 		} else {
 			activity.AttractionID = sql.NullInt64{Valid: false}
-			if order == 2 {
-				activity.Notes = "Welcome. Enjoy a relaxing walk in a nearby park after your flight."
-			} else {
-				activity.Notes = "Last day. Perfect time for souvenir shopping and a final city stroll."
-			}
+			activity.Notes = slot.Notes
 		}
 
 	}
@@ -412,10 +905,11 @@ func (s *TripPlanningService) saveActivity(tx *sql.Tx, itineraryID int64, aType
 			"order", order,
 			"error", err)
 	}
+	return err
 }
 
-func (s *TripPlanningService) FinalizeTripPlan(tripID int, tier string, hotelID int, outboundFlightID int, inboundFlightID int) error {
-	l := slog.With("trip_id", tripID, "tier", tier)
+func (s *TripPlanningService) finalizeOption(tripID int, option *models.TripOption) error {
+	l := slog.With("trip_id", tripID, "tier", option.Tier)
 	l.Info("Finalizing trip plan")
 
 	trip, err := s.TripRepo.GetTripByID(tripID)
@@ -431,28 +925,25 @@ func (s *TripPlanningService) FinalizeTripPlan(tripID int, tier string, hotelID
 	}
 	defer tx.Rollback()
 
-	activitiesBudget := trip.TotalPrice * 0.30
-	DurationDays := int(trip.EndDate.Sub(trip.StartDate).Hours()/24) + 1
-	err = s.PopulateItineraryDetails(
-		tx,
-		trip.TripID,
-		trip.DestinationCityID,
-		tier,
-		activitiesBudget,
-		DurationDays,
-		trip.StartDate,
-		hotelID,
-		outboundFlightID,
-		inboundFlightID,
-	)
+	// A selected option carries the activities budget its profile produced at generation time.
+	activitiesBudget := option.ActivitiesBudget
+	if activitiesBudget <= 0 {
+		activitiesBudget = SplitBudget(trip.TotalPrice, s.budgetProfile(trip.UserID)).Activities
+	}
+
+	// Confirm first: it locks the trip, and a finalized trip keeps its itinerary.
+	confirmed, err := s.TripRepo.Confirm(tx, tripID, option.Tier, activitiesBudget)
 	if err != nil {
-		l.Error("Failed to populate details", "error", err)
 		return err
 	}
+	if !confirmed {
+		l.Warn("Rejected selection for a finalized trip", "status", trip.Status)
+		return ErrTripAlreadyFinalized
+	}
 
-	updateStatusQuery := `UPDATE trips SET status = $1 WHERE trip_id = $2`
-	if _, err := tx.Exec(updateStatusQuery, "Confirmed", tripID); err != nil {
-		return fmt.Errorf("failed to update status: %w", err)
+	if err := s.PopulateItineraryDetails(tx, trip, option, activitiesBudget); err != nil {
+		l.Error("Failed to populate details", "error", err)
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -464,12 +955,29 @@ func (s *TripPlanningService) FinalizeTripPlan(tripID int, tier string, hotelID
 	return nil
 }
 
+func (s *TripPlanningService) GetScoringWeights() AttractionScoringWeights {
+	return s.ScoringWeights
+}
+
 func (s *TripPlanningService) GetTripByID(id int) (*models.Trip, error) {
 	return s.TripRepo.GetTripByID(id)
 }
 
+// GetUserTrips returns the user's own trips followed by the trips shared with them.
 func (s *TripPlanningService) GetUserTrips(userID int) ([]models.Trip, error) {
-	return s.TripRepo.GetAllTripsByUserID(userID)
+	trips, err := s.TripRepo.GetAllTripsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range trips {
+		trips[i].AccessRole = models.TripRoleOwner
+	}
+
+	shared, err := s.TripRepo.GetSharedTripsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return append(trips, shared...), nil
 }
 
 func (s *TripPlanningService) GetItineraryDays(tripID int) ([]*models.TripItinerary, error) {
@@ -480,21 +988,27 @@ func (s *TripPlanningService) GetActivitiesByDay(itineraryID int) ([]*models.Iti
 	return s.ItineraryActivitiesRepo.GetActivitiesByItineraryID(itineraryID)
 }
 
-func (s *TripPlanningService) UpdateTripStatus(tripID, userID int, newStatus string) error {
-	l := slog.With("trip_id", tripID, "user_id", userID, "new_status", newStatus)
+func (s *TripPlanningService) GetActivity(activityID int64) (*models.ItineraryActivity, error) {
+	return s.ItineraryActivitiesRepo.GetByID(activityID)
+}
 
-	trip, err := s.TripRepo.GetTripByID(tripID)
-	if err != nil {
-		l.Error("Trip not found", "error", err)
-		return fmt.Errorf("trip not found")
+// DeleteUserTrip removes a trip with its itinerary. Only the owner may delete it.
+func (s *TripPlanningService) DeleteUserTrip(tripID, userID int) error {
+	if _, err := s.AuthorizeTrip(tripID, userID, models.TripRoleOwner); err != nil {
+		return err
 	}
+	return s.TripRepo.DeleteByIDAndUserID(tripID, userID)
+}
 
-	if trip.UserID != userID {
-		l.Warn("Unauthorized status update attempt")
-		return fmt.Errorf("unauthorized")
+func (s *TripPlanningService) UpdateTripStatus(tripID, userID int, newStatus string) error {
+	l := slog.With("trip_id", tripID, "user_id", userID, "new_status", newStatus)
+
+	if _, err := s.AuthorizeTrip(tripID, userID, models.TripRoleOwner); err != nil {
+		l.Warn("Unauthorized status update attempt", "error", err)
+		return err
 	}
 
-	err = s.TripRepo.UpdateStatus(tripID, userID, newStatus)
+	err := s.TripRepo.UpdateStatus(tripID, userID, newStatus)
 	if err != nil {
 		l.Error("Failed to update status in database", "error", err)
 		return err
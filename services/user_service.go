@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -11,12 +12,14 @@ import (
 type UserService struct {
 	UserRepo            *repository.UserRepository
 	UserPreferencesRepo *repository.UserPreferencesRepository
+	BudgetProfileRepo   *repository.BudgetProfileRepository
 }
 
-func NewUserService(userRepo *repository.UserRepository, userPreferencesRepo *repository.UserPreferencesRepository) *UserService {
+func NewUserService(userRepo *repository.UserRepository, userPreferencesRepo *repository.UserPreferencesRepository, budgetProfileRepo *repository.BudgetProfileRepository) *UserService {
 	return &UserService{
 		UserRepo:            userRepo,
 		UserPreferencesRepo: userPreferencesRepo,
+		BudgetProfileRepo:   budgetProfileRepo,
 	}
 }
 
@@ -77,6 +80,86 @@ func (s *UserService) GetUserPreferences(userID int) (*models.UserPreferences, e
 	return prefs, nil
 }
 
+func (s *UserService) GetBudgetProfiles(userID int) ([]models.BudgetProfile, error) {
+	return s.BudgetProfileRepo.GetAvailableForUser(userID)
+}
+
+func (s *UserService) CreateBudgetProfile(userID int, profile models.BudgetProfile) (int, error) {
+	l := slog.With("user_id", userID, "profile", profile.Name)
+
+	if err := ValidateBudgetProfile(profile); err != nil {
+		l.Warn("Rejected budget profile", "error", err)
+		return 0, err
+	}
+
+	profile.UserID = &userID
+	profileID, err := s.BudgetProfileRepo.Insert(&profile)
+	if err != nil {
+		return 0, err
+	}
+
+	l.Info("Budget profile created", "profile_id", profileID)
+	return profileID, nil
+}
+
+func (s *UserService) UpdateBudgetProfile(userID int, profile models.BudgetProfile) error {
+	l := slog.With("user_id", userID, "profile_id", profile.ProfileID)
+
+	if err := ValidateBudgetProfile(profile); err != nil {
+		l.Warn("Rejected budget profile", "error", err)
+		return err
+	}
+
+	updated, err := s.BudgetProfileRepo.Update(&profile, userID)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrBudgetProfileNotFound
+	}
+
+	l.Info("Budget profile updated")
+	return nil
+}
+
+func (s *UserService) DeleteBudgetProfile(userID, profileID int) error {
+	deleted, err := s.BudgetProfileRepo.Delete(profileID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBudgetProfileNotFound
+	}
+
+	slog.Info("Budget profile deleted", "user_id", userID, "profile_id", profileID)
+	return nil
+}
+
+// SelectBudgetProfile makes a built-in or own profile the one used to plan the user's trips.
+func (s *UserService) SelectBudgetProfile(userID, profileID int) error {
+	l := slog.With("user_id", userID, "profile_id", profileID)
+
+	profile, err := s.BudgetProfileRepo.GetAvailableByID(profileID, userID)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return ErrBudgetProfileNotFound
+	}
+
+	saved, err := s.UserPreferencesRepo.SetBudgetProfile(userID, profileID)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		l.Warn("Budget profile selected before preferences were saved")
+		return fmt.Errorf("save your preferences before choosing a budget profile")
+	}
+
+	l.Info("Budget profile selected", "profile", profile.Name)
+	return nil
+}
+
 func (s *UserService) GetUserByEmail(email string) (*models.User, error) {
 	l := slog.With("email", email)
 	l.Debug("Attempting to fetch user by email")
@@ -90,3 +173,64 @@ func (s *UserService) GetUserByEmail(email string) (*models.User, error) {
 	l.Info("User fetched successfully", "email", user.Email)
 	return user, nil
 }
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrInvalidRole  = errors.New("role must be user or admin")
+)
+
+// SetUserRole grants or revokes the admin role. The change reaches the user's token on
+// their next login or token refresh.
+func (s *UserService) SetUserRole(userID int, role string) error {
+	if _, ok := userRoleRank[role]; !ok {
+		return ErrInvalidRole
+	}
+	found, err := s.UserRepo.SetRole(userID, role)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrUserNotFound
+	}
+	slog.Info("User role changed", "user_id", userID, "role", role)
+	return nil
+}
+
+// PromoteAdmins bootstraps the first admins of a fresh deployment from configuration: it
+// gives the admin role to the users registered with the emails, but only while no admin
+// exists yet. Registration does not verify email addresses, so whoever registers a listed
+// address first would become admin; once an admin exists, further admins are appointed
+// through the API and the list is ignored. Clear ADMIN_EMAILS after the bootstrap.
+func (s *UserService) PromoteAdmins(emails []string) {
+	var pending []string
+	for _, email := range emails {
+		if email = strings.TrimSpace(email); email != "" {
+			pending = append(pending, email)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	hasAdmin, err := s.UserRepo.HasAdmin()
+	if err != nil {
+		slog.Error("Skipping admin bootstrap", "error", err)
+		return
+	}
+	if hasAdmin {
+		slog.Warn("ADMIN_EMAILS ignored: an admin already exists")
+		return
+	}
+
+	for _, email := range pending {
+		found, err := s.UserRepo.SetRoleByEmail(email, models.UserRoleAdmin)
+		switch {
+		case err != nil:
+			slog.Error("Failed to promote admin", "email", email, "error", err)
+		case !found:
+			slog.Warn("Admin email is not registered", "email", email)
+		default:
+			slog.Info("Admin role granted", "email", email)
+		}
+	}
+}